@@ -17,6 +17,7 @@ import (
 const (
 	// Subsystem(s).
 	exporter = "exporter"
+	cluster  = "cluster"
 )
 
 // Metric descriptors.
@@ -26,6 +27,16 @@ var (
 		"Collector time duration.",
 		[]string{"collector"}, nil,
 	)
+	clusterInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, cluster, "info"),
+		"Information of the cluster, value is always 1.",
+		append(variables.BaseLabelNames, "version", "zapi_version", "serial_number", "location", "uuid"), nil,
+	)
+	collectorSupportedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, exporter, "collector_supported"),
+		"Whether the collector is supported by the ZAPI version of the NetAPP (1 for supported, 0 for skipped).",
+		[]string{"collector", "min_version"}, nil,
+	)
 )
 
 // Exporter collects NetAPP metrics. It implements prometheus.Collector.
@@ -37,6 +48,7 @@ type Exporter struct {
 	scrapeErrors *prometheus.CounterVec
 	netappUp     prometheus.Gauge
 	deviceConfig *config.DeviceConfig
+	ontapVersion *OntapVersion
 }
 
 var scrapers = []Scraper{
@@ -47,13 +59,35 @@ var scrapers = []Scraper{
 	metrics.ScrapeLun{},
 	metrics.ScrapeSnapshot{},
 	metrics.ScrapeStorageDisk{},
+	metrics.ScrapeCifsSession{},
+	metrics.ScrapeNfsClient{},
+	metrics.ScrapeQos{},
+	metrics.ScrapeSecurityCertificate{},
+	metrics.ScrapeClusterPeer{},
+	metrics.ScrapeFabricPool{},
+	metrics.ScrapeJob{},
+	metrics.ScrapeLicense{},
+	metrics.ScrapeServiceProcessor{},
+	metrics.ScrapeMetrocluster{},
+	metrics.ScrapeHealth{},
+	metrics.ScrapeTime{},
+	metrics.ScrapeExportPolicy{},
+	metrics.ScrapeSnapshotPolicy{},
+	metrics.ScrapeSnapvault{},
+	metrics.ScrapeAutosupport{},
+	metrics.ScrapeSnaplock{},
 }
 
-func New(Groupname string, netappClient *netapp.Client, deviceConfig *config.DeviceConfig) *Exporter {
+// New returns an Exporter for netappClient, ontapVersion may be nil when the
+// version is unknown, then all scrapers are run.
+func New(Groupname string, netappClient *netapp.Client, deviceConfig *config.DeviceConfig, ontapVersion *OntapVersion) *Exporter {
 	variables.BaseLabelValues[0] = Groupname
 	return &Exporter{
 		netappClient: netappClient,
-		scrapers:     append(scrapers, perf.New(deviceConfig.PerfData)),
+		scrapers: append(scrapers,
+			perf.New(deviceConfig.PerfData),
+			metrics.NewScrapeEms(deviceConfig.Ems.LogEvents, deviceConfig.Ems.Severities),
+		),
 		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: variables.Namespace,
 			Subsystem: exporter,
@@ -80,6 +114,7 @@ func New(Groupname string, netappClient *netapp.Client, deviceConfig *config.Dev
 		}),
 
 		deviceConfig: deviceConfig,
+		ontapVersion: ontapVersion,
 	}
 }
 
@@ -127,12 +162,19 @@ func (e *Exporter) scrape(ch chan<- prometheus.Metric) {
 	e.netappUp.Set(0)
 	ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "connection")
 
-	if clusterIdentity, ok := GetClusterIdentity(e.netappClient); ok {
+	if clusterIdentity, err := GetClusterIdentity(e.netappClient); err == nil {
 
 		e.netappUp.Set(1)
 		variables.BaseLabelValues[1] = clusterIdentity["clusterName"]
+		var release, zapiVersion string
+		if e.ontapVersion != nil {
+			release, zapiVersion = e.ontapVersion.Release, e.ontapVersion.Zapi.String()
+		}
+		ch <- prometheus.MustNewConstMetric(clusterInfoDesc, prometheus.GaugeValue, 1,
+			append(variables.BaseLabelValues, release, zapiVersion, clusterIdentity["clusterSerialNumber"], clusterIdentity["clusterLocation"], clusterIdentity["clusterUuid"])...)
 
 	} else {
+		log.Errorf("error when getting ClusterIdentity, %s", err)
 		e.netappUp.Set(0)
 		return
 	}
@@ -140,11 +182,17 @@ func (e *Exporter) scrape(ch chan<- prometheus.Metric) {
 	wg := &sync.WaitGroup{}
 	defer wg.Wait()
 	for _, scraper := range e.scrapers {
+		label := "collect." + scraper.Name()
+		if e.ontapVersion != nil && !e.ontapVersion.Zapi.AtLeast(scraper.Version()) {
+			log.Infof("Skipping %s, it needs ZAPI %s but the NetAPP supports %s", label, scraper.Version(), e.ontapVersion.Zapi)
+			ch <- prometheus.MustNewConstMetric(collectorSupportedDesc, prometheus.GaugeValue, 0, label, scraper.Version().String())
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(collectorSupportedDesc, prometheus.GaugeValue, 1, label, scraper.Version().String())
 		wg.Add(1)
 		go func(scraper Scraper) {
 			defer wg.Done()
 			log.Debug("start scraping" + scraper.Name())
-			label := "collect." + scraper.Name()
 			scrapeTime := time.Now()
 			if err := scraper.Scrape(e.netappClient, ch); err != nil {
 				log.Errorln("Error scraping for "+label+":", err)
@@ -157,7 +205,9 @@ func (e *Exporter) scrape(ch chan<- prometheus.Metric) {
 	}
 }
 
-func GetClusterIdentity(netappClient *netapp.Client) (map[string]string, bool) {
+// GetClusterIdentity returns the identity of the cluster, its error is the one
+// of the HTTP client, so that a failed TLS handshake can be told.
+func GetClusterIdentity(netappClient *netapp.Client) (map[string]string, error) {
 
 	clusterIdentity := make(map[string]string)
 	ops := &netapp.ClusterIdentityOptions{
@@ -165,12 +215,12 @@ func GetClusterIdentity(netappClient *netapp.Client) (map[string]string, bool) {
 	}
 
 	l, _, err := netappClient.ClusterIdentity.List(ops)
-	if err !=nil  {
-		log.Infof("error when getting ClusterIdentity, %s",err)
-		return clusterIdentity, false
+	if err != nil {
+		return clusterIdentity, err
 	}
 	clusterIdentity["clusterName"] = l.Results.ClusterIdentityInfo[0].ClusterName
 	clusterIdentity["clusterSerialNumber"] = l.Results.ClusterIdentityInfo[0].ClusterSerialNumber
 	clusterIdentity["clusterLocation"] = l.Results.ClusterIdentityInfo[0].ClusterLocation
-	return clusterIdentity,true 
+	clusterIdentity["clusterUuid"] = l.Results.ClusterIdentityInfo[0].UUID
+	return clusterIdentity, nil
 }
@@ -64,6 +64,11 @@ func (ScrapeAggr) Help() string {
 	return "Collect Netapp aggr info;"
 }
 
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeAggr) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 20}
+}
+
 type Aggregate struct {
 	Name                string
 	OwnerName           string
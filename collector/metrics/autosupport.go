@@ -0,0 +1,215 @@
+package metrics
+
+import (
+	"encoding/xml"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	AutosupportSubsystem = "autosupport"
+
+	// autosupportHistoryWindow is how far back the history of the messages is read.
+	autosupportHistoryWindow = 7 * 24 * time.Hour
+)
+
+// Metric descriptors.
+var (
+	autosupportLabels        = append(variables.BaseLabelNames, "node")
+	autosupportIsEnabledDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, AutosupportSubsystem, "is_enabled"),
+		"whether AutoSupport is enabled on the node.",
+		autosupportLabels, nil)
+	autosupportInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, AutosupportSubsystem, "info"),
+		"AutoSupport configuration of the node, transport is one of smtp, http or https.",
+		append(autosupportLabels, "transport", "is_support_enabled"), nil)
+
+	autosupportDestinationLabels = append(autosupportLabels, "destination")
+	autosupportLastSuccessDesc   = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, AutosupportSubsystem, "last_success_timestamp_seconds"),
+		"Time of the last AutoSupport message of the node sent successfully to the destination, however long ago.",
+		autosupportDestinationLabels, nil)
+	autosupportMessagesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, AutosupportSubsystem, "messages"),
+		"Number of AutoSupport messages of the node to the destination updated in the last 7 days by status; collection-failed, transmission-failed and wait-to-retry are always exported.",
+		append(autosupportDestinationLabels, "status"), nil)
+)
+
+// ScrapeAutosupport collects AutoSupport configuration and delivery info
+type ScrapeAutosupport struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeAutosupport) Name() string {
+	return AutosupportSubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeAutosupport) Help() string {
+	return "Collect Netapp AutoSupport configuration and delivery info;"
+}
+
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeAutosupport) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 20}
+}
+
+type AutosupportConfig struct {
+	Node             string
+	IsEnabled        bool
+	IsSupportEnabled string
+	Transport        string
+}
+
+type AutosupportHistory struct {
+	Node        string
+	Destination string
+	Status      string
+	LastUpdate  int64
+}
+
+type autosupportConfigInfo struct {
+	NodeName         string `xml:"node-name"`
+	IsEnabled        bool   `xml:"is-enabled"`
+	IsSupportEnabled string `xml:"is-support-enabled"`
+	Transport        string `xml:"transport"`
+}
+
+// autosupportHistoryInfo is both the desired attributes and the record of
+// autosupport-history-get-iter.
+type autosupportHistoryInfo struct {
+	XMLName     xml.Name `xml:"autosupport-history-info"`
+	NodeName    string   `xml:"node-name"`
+	Destination string   `xml:"destination"`
+	Status      string   `xml:"status"`
+	LastUpdate  string   `xml:"last-update"`
+}
+
+type autosupportHistoryQuery struct {
+	XMLName    xml.Name `xml:"autosupport-history-info"`
+	LastUpdate string   `xml:"last-update,omitempty"`
+	Status     string   `xml:"status,omitempty"`
+}
+
+// autosupportUndeliveredStatus are the status of the messages not delivered,
+// which are exported even when no message has them.
+var autosupportUndeliveredStatus = []string{
+	"collection-failed",
+	"transmission-failed",
+	"wait-to-retry",
+}
+
+// Scrape collects data from  netapp AutoSupport info
+func (ScrapeAutosupport) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	configs, err := GetAutosupportConfigData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, config := range configs {
+		autosupportLabelValues := append(variables.BaseLabelValues, config.Node)
+		ch <- prometheus.MustNewConstMetric(autosupportIsEnabledDesc, prometheus.GaugeValue, utils.BoolToFloat64(config.IsEnabled), autosupportLabelValues...)
+		ch <- prometheus.MustNewConstMetric(autosupportInfoDesc, prometheus.GaugeValue, 1, append(autosupportLabelValues, config.Transport, config.IsSupportEnabled)...)
+	}
+
+	history, err := GetAutosupportHistoryData(netappClient, time.Now().Add(-autosupportHistoryWindow).Unix(), "")
+	if err != nil {
+		return err
+	}
+	messages := make(map[[2]string]map[string]int)
+	for _, message := range history {
+		key := [2]string{message.Node, message.Destination}
+		if _, ok := messages[key]; !ok {
+			messages[key] = make(map[string]int)
+			for _, status := range autosupportUndeliveredStatus {
+				messages[key][status] = 0
+			}
+		}
+		messages[key][message.Status]++
+	}
+	for key, statuses := range messages {
+		for status, count := range statuses {
+			ch <- prometheus.MustNewConstMetric(autosupportMessagesDesc, prometheus.GaugeValue, float64(count), append(variables.BaseLabelValues, key[0], key[1], status)...)
+		}
+	}
+
+	// the successful messages are read without the window, so the last
+	// success is still known when the delivery is broken for longer.
+	sent, err := GetAutosupportHistoryData(netappClient, 0, "sent-successful")
+	if err != nil {
+		return err
+	}
+	lastSuccess := make(map[[2]string]int64)
+	for _, message := range sent {
+		key := [2]string{message.Node, message.Destination}
+		if message.Status == "sent-successful" && message.LastUpdate > lastSuccess[key] {
+			lastSuccess[key] = message.LastUpdate
+		}
+	}
+	for key, timestamp := range lastSuccess {
+		ch <- prometheus.MustNewConstMetric(autosupportLastSuccessDesc, prometheus.GaugeValue, float64(timestamp), append(variables.BaseLabelValues, key[0], key[1])...)
+	}
+	return nil
+}
+
+func GetAutosupportConfigData(netappClient *netapp.Client) (r []*AutosupportConfig, err error) {
+
+	var l struct {
+		AutosupportConfigInfo []autosupportConfigInfo `xml:"autosupport-config-info"`
+	}
+	if err = zapiGetIter(netappClient, "autosupport-config-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.AutosupportConfigInfo {
+		r = append(r, &AutosupportConfig{
+			Node:             n.NodeName,
+			IsEnabled:        n.IsEnabled,
+			IsSupportEnabled: n.IsSupportEnabled,
+			Transport:        n.Transport,
+		})
+	}
+	return
+}
+
+// GetAutosupportHistoryData returns the messages updated since the unix time
+// since with the status, 0 and "" match any.
+func GetAutosupportHistoryData(netappClient *netapp.Client, since int64, status string) (r []*AutosupportHistory, err error) {
+
+	query := &autosupportHistoryQuery{Status: status}
+	if since > 0 {
+		query.LastUpdate = ">=" + strconv.FormatInt(since, 10)
+	}
+	var l struct {
+		AutosupportHistoryInfo []autosupportHistoryInfo `xml:"autosupport-history-info"`
+	}
+	if err = zapiGetIterAttributes(netappClient, "autosupport-history-get-iter", query, &autosupportHistoryInfo{}, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.AutosupportHistoryInfo {
+		// a message being collected has no last update yet.
+		var lastUpdate int64
+		if n.LastUpdate != "" {
+			v, err := strconv.ParseInt(n.LastUpdate, 10, 64)
+			if err != nil {
+				log.Printf("autosupport-history-get-iter: last-update of %s to %s: %s", n.NodeName, n.Destination, err)
+			}
+			lastUpdate = v
+		}
+		r = append(r, &AutosupportHistory{
+			Node:        n.NodeName,
+			Destination: n.Destination,
+			Status:      n.Status,
+			LastUpdate:  lastUpdate,
+		})
+	}
+	return
+}
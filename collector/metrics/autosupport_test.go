@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const autosupportReplies = `<?xml version='1.0' encoding='UTF-8' ?>
+<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'>
+<results status="passed">
+<attributes-list>
+<autosupport-history-info><destination>http</destination><last-update>1593694800</last-update><node-name>node-01</node-name><status>sent-successful</status></autosupport-history-info>
+<autosupport-history-info><destination>http</destination><last-update>1593698400</last-update><node-name>node-01</node-name><status>transmission-failed</status></autosupport-history-info>
+<autosupport-history-info><destination>http</destination><last-update>1593702000</last-update><node-name>node-01</node-name><status>wait-to-retry</status></autosupport-history-info>
+<autosupport-history-info><destination>smtp</destination><last-update>1593702000</last-update><node-name>node-01</node-name><status>collection-failed</status></autosupport-history-info>
+<autosupport-history-info><destination>smtp</destination><node-name>node-01</node-name><status>collection-in-progress</status></autosupport-history-info>
+</attributes-list>
+<num-records>5</num-records>
+</results>
+</netapp>`
+
+// TestScrapeAutosupportHistory checks the messages are counted by status, the
+// undelivered status being exported even without messages, and the last
+// success is the newest successful message.
+func TestScrapeAutosupportHistory(t *testing.T) {
+	netappClient, stop := newZapiTestClient(map[string]string{
+		"autosupport-config-get-iter":  `<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'><results status="passed"><num-records>0</num-records></results></netapp>`,
+		"autosupport-history-get-iter": autosupportReplies,
+	})
+	defer stop()
+
+	expected := `
+# HELP netapp_autosupport_last_success_timestamp_seconds Time of the last AutoSupport message of the node sent successfully to the destination, however long ago.
+# TYPE netapp_autosupport_last_success_timestamp_seconds gauge
+netapp_autosupport_last_success_timestamp_seconds{cluster="",destination="http",group="",node="node-01"} 1.5936948e+09
+# HELP netapp_autosupport_messages Number of AutoSupport messages of the node to the destination updated in the last 7 days by status; collection-failed, transmission-failed and wait-to-retry are always exported.
+# TYPE netapp_autosupport_messages gauge
+netapp_autosupport_messages{cluster="",destination="http",group="",node="node-01",status="collection-failed"} 0
+netapp_autosupport_messages{cluster="",destination="http",group="",node="node-01",status="sent-successful"} 1
+netapp_autosupport_messages{cluster="",destination="http",group="",node="node-01",status="transmission-failed"} 1
+netapp_autosupport_messages{cluster="",destination="http",group="",node="node-01",status="wait-to-retry"} 1
+netapp_autosupport_messages{cluster="",destination="smtp",group="",node="node-01",status="collection-failed"} 1
+netapp_autosupport_messages{cluster="",destination="smtp",group="",node="node-01",status="collection-in-progress"} 1
+netapp_autosupport_messages{cluster="",destination="smtp",group="",node="node-01",status="transmission-failed"} 0
+netapp_autosupport_messages{cluster="",destination="smtp",group="",node="node-01",status="wait-to-retry"} 0
+`
+	c := scraperCollector{t: t, scraper: ScrapeAutosupport{}, netappClient: netappClient}
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected), "netapp_autosupport_messages", "netapp_autosupport_last_success_timestamp_seconds"); err != nil {
+		t.Error(err)
+	}
+}
+
+const autosupportConfigReply = `<?xml version='1.0' encoding='UTF-8' ?>
+<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'>
+<results status="passed">
+<attributes-list>
+<autosupport-config-info><is-enabled>true</is-enabled><is-support-enabled>true</is-support-enabled><node-name>node-01</node-name><transport>https</transport></autosupport-config-info>
+<autosupport-config-info><is-enabled>false</is-enabled><is-support-enabled>false</is-support-enabled><node-name>node-02</node-name><transport>smtp</transport></autosupport-config-info>
+</attributes-list>
+<num-records>2</num-records>
+</results>
+</netapp>`
+
+// TestScrapeAutosupportConfig checks the AutoSupport configuration of each
+// node is exported.
+func TestScrapeAutosupportConfig(t *testing.T) {
+	netappClient, stop := newZapiTestClient(map[string]string{
+		"autosupport-config-get-iter":  autosupportConfigReply,
+		"autosupport-history-get-iter": `<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'><results status="passed"><num-records>0</num-records></results></netapp>`,
+	})
+	defer stop()
+
+	expected := `
+# HELP netapp_autosupport_info AutoSupport configuration of the node, transport is one of smtp, http or https.
+# TYPE netapp_autosupport_info gauge
+netapp_autosupport_info{cluster="",group="",is_support_enabled="false",node="node-02",transport="smtp"} 1
+netapp_autosupport_info{cluster="",group="",is_support_enabled="true",node="node-01",transport="https"} 1
+# HELP netapp_autosupport_is_enabled whether AutoSupport is enabled on the node.
+# TYPE netapp_autosupport_is_enabled gauge
+netapp_autosupport_is_enabled{cluster="",group="",node="node-01"} 1
+netapp_autosupport_is_enabled{cluster="",group="",node="node-02"} 0
+`
+	c := scraperCollector{t: t, scraper: ScrapeAutosupport{}, netappClient: netappClient}
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected), "netapp_autosupport_info", "netapp_autosupport_is_enabled"); err != nil {
+		t.Error(err)
+	}
+}
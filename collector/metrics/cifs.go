@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	CifsSubsystem = "cifs"
+)
+
+// Metric descriptors.
+var (
+	cifsSessionLabels = append(variables.BaseLabelNames, "vserver", "node", "lif", "protocol_version")
+	cifsSessionsDesc  = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, CifsSubsystem, "sessions"),
+		"Number of CIFS sessions on the lif.",
+		cifsSessionLabels, nil)
+	cifsOpenFilesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, CifsSubsystem, "open_files"),
+		"Number of files opened by the CIFS sessions on the lif.",
+		cifsSessionLabels, nil)
+	cifsConnectedClientsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, CifsSubsystem, "connected_clients"),
+		"Number of distinct client addresses with a CIFS session on the lif.",
+		cifsSessionLabels, nil)
+)
+
+// ScrapeCifsSession collects CIFS session info
+type ScrapeCifsSession struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeCifsSession) Name() string {
+	return CifsSubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeCifsSession) Help() string {
+	return "Collect Netapp CIFS session info;"
+}
+
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeCifsSession) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 21}
+}
+
+type CifsSession struct {
+	Vserver         string
+	Node            string
+	LifAddress      string
+	Address         string
+	ProtocolVersion string
+	Files           int
+}
+
+type cifsSessionInfo struct {
+	Vserver         string `xml:"vserver"`
+	Node            string `xml:"node"`
+	LifAddress      string `xml:"lif-address"`
+	Address         string `xml:"address"`
+	ProtocolVersion string `xml:"protocol-version"`
+	Files           int    `xml:"files"`
+}
+
+// cifsSessionKey is the set of labels the sessions are counted by.
+type cifsSessionKey struct {
+	Vserver         string
+	Node            string
+	LifAddress      string
+	ProtocolVersion string
+}
+
+// Scrape collects data from  netapp CIFS session info
+func (ScrapeCifsSession) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	sessions, err := GetCifsSessionData(netappClient)
+	if err != nil {
+		return err
+	}
+
+	sessionCount := make(map[cifsSessionKey]int)
+	openFiles := make(map[cifsSessionKey]int)
+	clients := make(map[cifsSessionKey]map[string]bool)
+	for _, session := range sessions {
+		key := cifsSessionKey{session.Vserver, session.Node, session.LifAddress, session.ProtocolVersion}
+		sessionCount[key]++
+		openFiles[key] += session.Files
+		if clients[key] == nil {
+			clients[key] = make(map[string]bool)
+		}
+		clients[key][session.Address] = true
+	}
+
+	for key, count := range sessionCount {
+		cifsSessionLabelValues := append(variables.BaseLabelValues, key.Vserver, key.Node, key.LifAddress, key.ProtocolVersion)
+		ch <- prometheus.MustNewConstMetric(cifsSessionsDesc, prometheus.GaugeValue, float64(count), cifsSessionLabelValues...)
+		ch <- prometheus.MustNewConstMetric(cifsOpenFilesDesc, prometheus.GaugeValue, float64(openFiles[key]), cifsSessionLabelValues...)
+		ch <- prometheus.MustNewConstMetric(cifsConnectedClientsDesc, prometheus.GaugeValue, float64(len(clients[key])), cifsSessionLabelValues...)
+	}
+	return nil
+}
+
+func GetCifsSessionData(netappClient *netapp.Client) (r []*CifsSession, err error) {
+
+	var l struct {
+		CifsSession []cifsSessionInfo `xml:"cifs-session"`
+	}
+	if err = zapiGetIter(netappClient, "cifs-session-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.CifsSession {
+		r = append(r, &CifsSession{
+			Vserver:         n.Vserver,
+			Node:            n.Node,
+			LifAddress:      n.LifAddress,
+			Address:         n.Address,
+			ProtocolVersion: n.ProtocolVersion,
+			Files:           n.Files,
+		})
+	}
+	return
+}
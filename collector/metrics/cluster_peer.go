@@ -0,0 +1,266 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	ClusterPeerSubsystem = "cluster_peer"
+	VserverPeerSubsystem = "vserver_peer"
+)
+
+// Metric descriptors.
+var (
+	clusterPeerLabels           = append(variables.BaseLabelNames, "remote_cluster")
+	clusterPeerAvailabilityDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ClusterPeerSubsystem, "availability"),
+		"Availability of the peer cluster, 1(available), 0(unavailable), 2(partial), 3(pending), or 4(unidentified).",
+		clusterPeerLabels, nil)
+	clusterPeerHealthyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ClusterPeerSubsystem, "is_healthy"),
+		"whether the peer cluster is healthy.",
+		clusterPeerLabels, nil)
+	clusterPeerAddressesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ClusterPeerSubsystem, "addresses"),
+		"Number of configured addresses of the peer cluster.",
+		clusterPeerLabels, nil)
+	clusterPeerActiveAddressesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ClusterPeerSubsystem, "active_addresses"),
+		"Number of reachable addresses of the peer cluster.",
+		clusterPeerLabels, nil)
+
+	clusterPeerNodeLabels        = append(variables.BaseLabelNames, "node", "remote_cluster", "remote_node")
+	clusterPeerNodeAvailableDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ClusterPeerSubsystem, "node_is_available"),
+		"whether the remote node is available from the node.",
+		clusterPeerNodeLabels, nil)
+	clusterPeerNodeReachableDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ClusterPeerSubsystem, "node_is_reachable"),
+		"whether the data ping of the remote node from the node succeeded.",
+		clusterPeerNodeLabels, nil)
+	clusterPeerNodeLastPingDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ClusterPeerSubsystem, "node_last_successful_ping_timestamp_seconds"),
+		"Time of the last health check of the remote node from the node with a successful data ping, seen since the exporter started.",
+		clusterPeerNodeLabels, nil)
+
+	vserverPeerLabels    = append(variables.BaseLabelNames, "vserver", "remote_vserver", "remote_cluster")
+	vserverPeerStateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, VserverPeerSubsystem, "state"),
+		"State of the vserver peer, 1(peered), 2(pending), 3(initializing), 4(initiated), 5(rejected), 6(suspended), or 7(deleted).",
+		vserverPeerLabels, nil)
+)
+
+var (
+	clusterPeerAvailability = map[string]float64{
+		"unavailable":  0,
+		"available":    1,
+		"partial":      2,
+		"pending":      3,
+		"unidentified": 4,
+	}
+	vserverPeerState = map[string]float64{
+		"peered":       1,
+		"pending":      2,
+		"initializing": 3,
+		"initiated":    4,
+		"rejected":     5,
+		"suspended":    6,
+		"deleted":      7,
+	}
+)
+
+var (
+	clusterPeerLastPingsMu sync.Mutex
+	clusterPeerLastPings   = make(map[string]int64)
+)
+
+// clusterPeerLastPing returns the time of the last successful data ping of the
+// health record of target, which is updated whether the ping succeeds or not.
+func clusterPeerLastPing(target string, health *ClusterPeerHealth) (int64, bool) {
+	key := target + "/" + health.OriginatingNode + "/" + health.DestinationCluster + "/" + health.DestinationNode
+
+	clusterPeerLastPingsMu.Lock()
+	defer clusterPeerLastPingsMu.Unlock()
+	if health.DataPing == "interface_reachable" && health.LastUpdated > clusterPeerLastPings[key] {
+		clusterPeerLastPings[key] = health.LastUpdated
+	}
+	lastPing, ok := clusterPeerLastPings[key]
+	return lastPing, ok
+}
+
+// ScrapeClusterPeer collects cluster peer and vserver peer info
+type ScrapeClusterPeer struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeClusterPeer) Name() string {
+	return ClusterPeerSubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeClusterPeer) Help() string {
+	return "Collect Netapp cluster peer and vserver peer info;"
+}
+
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeClusterPeer) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 30}
+}
+
+type ClusterPeer struct {
+	RemoteClusterName string
+	Availability      string
+	IsClusterHealthy  bool
+	PeerAddresses     []string
+	ActiveAddresses   []string
+}
+
+type ClusterPeerHealth struct {
+	OriginatingNode            string
+	DestinationCluster         string
+	DestinationNode            string
+	IsDestinationNodeAvailable bool
+	DataPing                   string
+	LastUpdated                int64
+}
+
+type VserverPeer struct {
+	Vserver     string
+	PeerVserver string
+	PeerCluster string
+	PeerState   string
+}
+
+type clusterPeerInfo struct {
+	RemoteClusterName string   `xml:"remote-cluster-name"`
+	Availability      string   `xml:"availability"`
+	IsClusterHealthy  bool     `xml:"is-cluster-healthy"`
+	PeerAddresses     []string `xml:"peer-addresses>remote-inet-address"`
+	ActiveAddresses   []string `xml:"active-addresses>remote-inet-address"`
+}
+
+type clusterPeerHealthInfo struct {
+	OriginatingNode            string `xml:"originating-node"`
+	DestinationCluster         string `xml:"destination-cluster"`
+	DestinationNode            string `xml:"destination-node"`
+	IsDestinationNodeAvailable bool   `xml:"is-destination-node-available"`
+	DataPing                   string `xml:"data-ping"`
+	LastUpdated                int64  `xml:"last-updated"`
+}
+
+type vserverPeerInfo struct {
+	Vserver     string `xml:"vserver"`
+	PeerVserver string `xml:"peer-vserver"`
+	PeerCluster string `xml:"peer-cluster"`
+	PeerState   string `xml:"peer-state"`
+}
+
+// Scrape collects data from  netapp cluster peer and vserver peer info
+func (ScrapeClusterPeer) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	clusterPeers, err := GetClusterPeerData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, clusterPeer := range clusterPeers {
+		clusterPeerLabelValues := append(variables.BaseLabelValues, clusterPeer.RemoteClusterName)
+		if value, ok := clusterPeerAvailability[clusterPeer.Availability]; ok {
+			ch <- prometheus.MustNewConstMetric(clusterPeerAvailabilityDesc, prometheus.GaugeValue, value, clusterPeerLabelValues...)
+		}
+		ch <- prometheus.MustNewConstMetric(clusterPeerHealthyDesc, prometheus.GaugeValue, utils.BoolToFloat64(clusterPeer.IsClusterHealthy), clusterPeerLabelValues...)
+		ch <- prometheus.MustNewConstMetric(clusterPeerAddressesDesc, prometheus.GaugeValue, float64(len(clusterPeer.PeerAddresses)), clusterPeerLabelValues...)
+		ch <- prometheus.MustNewConstMetric(clusterPeerActiveAddressesDesc, prometheus.GaugeValue, float64(len(clusterPeer.ActiveAddresses)), clusterPeerLabelValues...)
+	}
+
+	clusterPeerHealths, err := GetClusterPeerHealthData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, health := range clusterPeerHealths {
+		clusterPeerNodeLabelValues := append(variables.BaseLabelValues, health.OriginatingNode, health.DestinationCluster, health.DestinationNode)
+		ch <- prometheus.MustNewConstMetric(clusterPeerNodeAvailableDesc, prometheus.GaugeValue, utils.BoolToFloat64(health.IsDestinationNodeAvailable), clusterPeerNodeLabelValues...)
+		ch <- prometheus.MustNewConstMetric(clusterPeerNodeReachableDesc, prometheus.GaugeValue, utils.BoolToFloat64(health.DataPing == "interface_reachable"), clusterPeerNodeLabelValues...)
+		if lastPing, ok := clusterPeerLastPing(netappClient.BaseURL.Host, health); ok {
+			ch <- prometheus.MustNewConstMetric(clusterPeerNodeLastPingDesc, prometheus.GaugeValue, float64(lastPing), clusterPeerNodeLabelValues...)
+		}
+	}
+
+	vserverPeers, err := GetVserverPeerData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, vserverPeer := range vserverPeers {
+		if value, ok := vserverPeerState[vserverPeer.PeerState]; ok {
+			ch <- prometheus.MustNewConstMetric(vserverPeerStateDesc, prometheus.GaugeValue, value, append(variables.BaseLabelValues, vserverPeer.Vserver, vserverPeer.PeerVserver, vserverPeer.PeerCluster)...)
+		}
+	}
+	return nil
+}
+
+func GetClusterPeerData(netappClient *netapp.Client) (r []*ClusterPeer, err error) {
+
+	var l struct {
+		ClusterPeerInfo []clusterPeerInfo `xml:"cluster-peer-info"`
+	}
+	if err = zapiGetIter(netappClient, "cluster-peer-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.ClusterPeerInfo {
+		r = append(r, &ClusterPeer{
+			RemoteClusterName: n.RemoteClusterName,
+			Availability:      n.Availability,
+			IsClusterHealthy:  n.IsClusterHealthy,
+			PeerAddresses:     n.PeerAddresses,
+			ActiveAddresses:   n.ActiveAddresses,
+		})
+	}
+	return
+}
+
+func GetClusterPeerHealthData(netappClient *netapp.Client) (r []*ClusterPeerHealth, err error) {
+
+	var l struct {
+		ClusterPeerHealthInfo []clusterPeerHealthInfo `xml:"cluster-peer-health-info"`
+	}
+	if err = zapiGetIter(netappClient, "cluster-peer-health-info-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.ClusterPeerHealthInfo {
+		r = append(r, &ClusterPeerHealth{
+			OriginatingNode:            n.OriginatingNode,
+			DestinationCluster:         n.DestinationCluster,
+			DestinationNode:            n.DestinationNode,
+			IsDestinationNodeAvailable: n.IsDestinationNodeAvailable,
+			DataPing:                   n.DataPing,
+			LastUpdated:                n.LastUpdated,
+		})
+	}
+	return
+}
+
+func GetVserverPeerData(netappClient *netapp.Client) (r []*VserverPeer, err error) {
+
+	var l struct {
+		VserverPeerInfo []vserverPeerInfo `xml:"vserver-peer-info"`
+	}
+	if err = zapiGetIter(netappClient, "vserver-peer-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.VserverPeerInfo {
+		r = append(r, &VserverPeer{
+			Vserver:     n.Vserver,
+			PeerVserver: n.PeerVserver,
+			PeerCluster: n.PeerCluster,
+			PeerState:   n.PeerState,
+		})
+	}
+	return
+}
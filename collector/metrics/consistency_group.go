@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	ConsistencyGroupSubsystem = "consistency_group"
+)
+
+// Metric descriptors.
+var (
+	consistencyGroupLabels   = append(variables.BaseLabelNames, "source_location", "destination_location", "destination_vserver")
+	consistencyGroupInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ConsistencyGroupSubsystem, "info"),
+		"Information of the consistency group relationship, locations are of the form vserver:/cg/name; consistency groups without a SnapMirror relationship are not exported.",
+		append(consistencyGroupLabels, "policy", "mirror_state", "relationship_status"), nil)
+	consistencyGroupVolumeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ConsistencyGroupSubsystem, "volume_info"),
+		"Membership of the volume in the consistency group, with the destination volume it is replicated to.",
+		append(consistencyGroupLabels, "source_volume", "destination_volume"), nil)
+	consistencyGroupNewestSnapshotDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ConsistencyGroupSubsystem, "newest_snapshot_timestamp_seconds"),
+		"Creation time of the newest consistency group snapshot replicated to the destination.",
+		consistencyGroupLabels, nil)
+	consistencyGroupIsHealthyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ConsistencyGroupSubsystem, "is_healthy"),
+		"whether the consistency group relationship is healthy.",
+		consistencyGroupLabels, nil)
+)
+
+var (
+	consistencyGroupNoticesMu sync.Mutex
+	// consistencyGroupNotices holds the targets the consistency group gap
+	// was logged for, by host.
+	consistencyGroupNotices = make(map[string]bool)
+)
+
+// scrapeConsistencyGroups exports the consistency group relationships among
+// relationships, it is called by ScrapeSnapvault which reads them anyway.
+//
+// ZAPI only knows consistency groups through their SnapMirror relationship,
+// local consistency groups can't be listed. This is logged once per target
+// whose relationships report a group type, i.e. which supports consistency
+// groups.
+func scrapeConsistencyGroups(netappClient *netapp.Client, relationships []*SnapmirrorRelationship, ch chan<- prometheus.Metric) {
+
+	for _, relationship := range relationships {
+		if relationship.RelationshipGroupType != "" {
+			logConsistencyGroupGap(netappClient.BaseURL.Host)
+		}
+		if !isConsistencyGroup(relationship) {
+			continue
+		}
+		consistencyGroupLabelValues := append(variables.BaseLabelValues, relationship.SourceLocation, relationship.DestinationLocation, relationship.DestinationVserver)
+		ch <- prometheus.MustNewConstMetric(consistencyGroupInfoDesc, prometheus.GaugeValue, 1, append(consistencyGroupLabelValues, relationship.Policy, relationship.MirrorState, relationship.RelationshipStatus)...)
+		ch <- prometheus.MustNewConstMetric(consistencyGroupIsHealthyDesc, prometheus.GaugeValue, utils.BoolToFloat64(relationship.IsHealthy), consistencyGroupLabelValues...)
+		if relationship.NewestSnapshotTimestamp > 0 {
+			ch <- prometheus.MustNewConstMetric(consistencyGroupNewestSnapshotDesc, prometheus.GaugeValue, float64(relationship.NewestSnapshotTimestamp), consistencyGroupLabelValues...)
+		}
+		for _, mapping := range relationship.CgItemMappings {
+			// an item mapping is of the form <source volume>:@<destination volume>.
+			items := strings.SplitN(mapping, ":@", 2)
+			if len(items) != 2 {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(consistencyGroupVolumeDesc, prometheus.GaugeValue, 1, append(consistencyGroupLabelValues, items[0], items[1])...)
+		}
+	}
+}
+
+func isConsistencyGroup(relationship *SnapmirrorRelationship) bool {
+	return relationship.RelationshipGroupType == "consistencygroup"
+}
+
+func logConsistencyGroupGap(host string) {
+	consistencyGroupNoticesMu.Lock()
+	defer consistencyGroupNoticesMu.Unlock()
+	if consistencyGroupNotices[host] {
+		return
+	}
+	consistencyGroupNotices[host] = true
+	log.Printf("%s supports consistency groups, only those with a SnapMirror relationship are visible through ZAPI and exported", host)
+}
@@ -0,0 +1,212 @@
+package metrics
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	EmsSubsystem = "ems"
+	// emsLookback is how far back the first scrape of a target reads the
+	// events to find the newest ones, which are not counted.
+	emsLookback = 10 * time.Minute
+)
+
+// Metric descriptors.
+var (
+	emsEventLabels     = append(variables.BaseLabelNames, "node", "severity", "message_name")
+	emsEventsTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, EmsSubsystem, "events_total"),
+		"Number of EMS events seen since the exporter started.",
+		emsEventLabels, nil)
+)
+
+// emsEventLogger writes the forwarded events, one JSON document per line.
+var emsEventLogger = log.New(os.Stdout, "", 0)
+
+// ScrapeEms collects EMS events
+type ScrapeEms struct {
+	LogEvents  bool
+	Severities []string
+}
+
+// Constructor to set whether events are logged and the severities to read
+func NewScrapeEms(logEvents bool, severities []string) *ScrapeEms {
+	return &ScrapeEms{
+		LogEvents:  logEvents,
+		Severities: severities,
+	}
+}
+
+// Name of the Scraper. Should be unique.
+func (se *ScrapeEms) Name() string {
+	return EmsSubsystem
+}
+
+// Help describes the role of the Scraper.
+func (se *ScrapeEms) Help() string {
+	return "Collect Netapp EMS events;"
+}
+
+// Version of ZAPI from which the Scraper is available.
+func (se *ScrapeEms) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 20}
+}
+
+type EmsEvent struct {
+	Target      string `json:"target"`
+	Cluster     string `json:"cluster"`
+	Node        string `json:"node"`
+	SeqNum      int64  `json:"seq_num"`
+	Time        int64  `json:"time"`
+	MessageName string `json:"message_name"`
+	Severity    string `json:"severity"`
+	Event       string `json:"event"`
+	Source      string `json:"source"`
+}
+
+type emsMessageInfo struct {
+	Node        string `xml:"node"`
+	SeqNum      int64  `xml:"seq-num"`
+	Time        int64  `xml:"time"`
+	MessageName string `xml:"message-name"`
+	Severity    string `xml:"severity"`
+	Event       string `xml:"event"`
+	Source      string `xml:"source"`
+}
+
+type emsMessageQuery struct {
+	XMLName  xml.Name `xml:"ems-message-info"`
+	Severity string   `xml:"severity,omitempty"`
+	Time     string   `xml:"time,omitempty"`
+}
+
+type emsEventKey struct {
+	Node        string
+	Severity    string
+	MessageName string
+}
+
+// emsState is what is remembered of a target between scrapes, as the
+// Exporter is created for every scrape.
+type emsState struct {
+	sync.Mutex
+	// started is false until the first scrape of the target, which only
+	// records the newest events, so that a restart of the exporter doesn't
+	// count the events of the lookback again.
+	started    bool
+	lastTime   int64
+	lastSeqNum map[string]int64
+	events     map[emsEventKey]float64
+}
+
+var (
+	emsStatesMu sync.Mutex
+	emsStates   = make(map[string]*emsState)
+)
+
+func getEmsState(target string) *emsState {
+	emsStatesMu.Lock()
+	defer emsStatesMu.Unlock()
+	state, ok := emsStates[target]
+	if !ok {
+		state = &emsState{
+			lastTime:   time.Now().Add(-emsLookback).Unix(),
+			lastSeqNum: make(map[string]int64),
+			events:     make(map[emsEventKey]float64),
+		}
+		emsStates[target] = state
+	}
+	return state
+}
+
+// Scrape collects data from  netapp EMS events
+func (se *ScrapeEms) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	target := netappClient.BaseURL.Host
+	state := getEmsState(target)
+	state.Lock()
+	defer state.Unlock()
+
+	events, err := GetEmsData(netappClient, state.lastTime, se.Severities)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		// sequence numbers are per node, the time query returns the events of
+		// the last second again.
+		if event.SeqNum <= state.lastSeqNum[event.Node] {
+			continue
+		}
+		state.lastSeqNum[event.Node] = event.SeqNum
+		if event.Time > state.lastTime {
+			state.lastTime = event.Time
+		}
+		if !state.started {
+			continue
+		}
+		state.events[emsEventKey{event.Node, event.Severity, event.MessageName}]++
+
+		if se.LogEvents {
+			event.Target = target
+			event.Cluster = variables.BaseLabelValues[1]
+			if b, err := json.Marshal(event); err == nil {
+				emsEventLogger.Println(string(b))
+			}
+		}
+	}
+	state.started = true
+
+	for key, count := range state.events {
+		ch <- prometheus.MustNewConstMetric(emsEventsTotalDesc, prometheus.CounterValue, count, append(variables.BaseLabelValues, key.Node, key.Severity, key.MessageName)...)
+	}
+	return nil
+}
+
+// GetEmsData returns the events since the unix time since, ordered by node and
+// sequence number.
+func GetEmsData(netappClient *netapp.Client, since int64, severities []string) (r []*EmsEvent, err error) {
+
+	query := &emsMessageQuery{
+		Severity: strings.Join(severities, "|"),
+		Time:     ">=" + strconv.FormatInt(since, 10),
+	}
+	var l struct {
+		EmsMessageInfo []emsMessageInfo `xml:"ems-message-info"`
+	}
+	if err = zapiGetIter(netappClient, "ems-message-get-iter", query, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.EmsMessageInfo {
+		r = append(r, &EmsEvent{
+			Node:        n.Node,
+			SeqNum:      n.SeqNum,
+			Time:        n.Time,
+			MessageName: n.MessageName,
+			Severity:    n.Severity,
+			Event:       n.Event,
+			Source:      n.Source,
+		})
+	}
+	sort.Slice(r, func(i, j int) bool {
+		if r[i].Node != r[j].Node {
+			return r[i].Node < r[j].Node
+		}
+		return r[i].SeqNum < r[j].SeqNum
+	})
+	return
+}
@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metric descriptors.
+var (
+	emsReceivedEventsTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, EmsSubsystem, "received_events_total"),
+		"Number of EMS events pushed to the exporter since it started.",
+		emsEventLabels, nil)
+	emsReceivedLastEventDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, EmsSubsystem, "received_last_event_timestamp_seconds"),
+		"Time of the last EMS event pushed to the exporter.",
+		emsEventLabels, nil)
+)
+
+// emsSyslogPattern matches the "[node:message.name:severity]: event" or
+// "[node: process: message.name:severity]: event" part of an EMS syslog line.
+var emsSyslogPattern = regexp.MustCompile(`\[\s*([^:\]]+?)\s*:(?:\s*([^:\]]+?)\s*:)?\s*([^:\]\s]+)\s*:\s*([^:\]\s]+)\s*\]:?\s*(.*)$`)
+
+// EmsReceiverCollector exports the EMS events pushed to the exporter for a
+// target by syslog or the webhook. It implements prometheus.Collector and is
+// registered on its own, the events don't need the NetAPP to be reachable.
+type EmsReceiverCollector struct {
+	target string
+}
+
+// NewEmsReceiverCollector returns an EmsReceiverCollector for the events pushed
+// for target.
+func NewEmsReceiverCollector(target string) *EmsReceiverCollector {
+	return &EmsReceiverCollector{target: target}
+}
+
+// Describe implements prometheus.Collector.
+func (c *EmsReceiverCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- emsReceivedEventsTotalDesc
+	ch <- emsReceivedLastEventDesc
+}
+
+// emsReceived is what has been pushed for a target.
+type emsReceived struct {
+	events    map[emsEventKey]float64
+	lastEvent map[emsEventKey]float64
+}
+
+var (
+	emsReceivedMu sync.Mutex
+	emsReceivedBy = make(map[string]*emsReceived)
+)
+
+// Collect implements prometheus.Collector.
+func (c *EmsReceiverCollector) Collect(ch chan<- prometheus.Metric) {
+
+	// the counts are copied, so that a slow scrape doesn't hold up the receiver.
+	events := make(map[emsEventKey]float64)
+	lastEvent := make(map[emsEventKey]float64)
+	emsReceivedMu.Lock()
+	if received, ok := emsReceivedBy[c.target]; ok {
+		for key, count := range received.events {
+			events[key] = count
+			lastEvent[key] = received.lastEvent[key]
+		}
+	}
+	emsReceivedMu.Unlock()
+
+	for key, count := range events {
+		emsEventLabelValues := append(variables.BaseLabelValues, key.Node, key.Severity, key.MessageName)
+		ch <- prometheus.MustNewConstMetric(emsReceivedEventsTotalDesc, prometheus.CounterValue, count, emsEventLabelValues...)
+		ch <- prometheus.MustNewConstMetric(emsReceivedLastEventDesc, prometheus.GaugeValue, lastEvent[key], emsEventLabelValues...)
+	}
+}
+
+// RecordEmsEvent counts an event pushed by target, logging it as JSON if logEvent is set.
+func RecordEmsEvent(target string, event *EmsEvent, logEvent bool) {
+	if event.Time == 0 {
+		event.Time = time.Now().Unix()
+	}
+	key := emsEventKey{event.Node, strings.ToLower(event.Severity), event.MessageName}
+
+	emsReceivedMu.Lock()
+	received, ok := emsReceivedBy[target]
+	if !ok {
+		received = &emsReceived{
+			events:    make(map[emsEventKey]float64),
+			lastEvent: make(map[emsEventKey]float64),
+		}
+		emsReceivedBy[target] = received
+	}
+	received.events[key]++
+	received.lastEvent[key] = float64(event.Time)
+	emsReceivedMu.Unlock()
+
+	if logEvent {
+		event.Target = target
+		if b, err := json.Marshal(event); err == nil {
+			emsEventLogger.Println(string(b))
+		}
+	}
+}
+
+// ParseEmsSyslog parses an EMS event sent to a syslog destination.
+func ParseEmsSyslog(line string) (*EmsEvent, error) {
+	m := emsSyslogPattern.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return nil, fmt.Errorf("not an EMS syslog message: %q", line)
+	}
+	return &EmsEvent{
+		Node:        m[1],
+		Source:      m[2],
+		MessageName: m[3],
+		Severity:    m[4],
+		Event:       m[5],
+	}, nil
+}
+
+type emsNotification struct {
+	emsMessageInfo
+	EmsSeverity string `xml:"ems-severity"`
+}
+
+// ParseEmsXML parses an EMS event sent to a rest-api destination, either an
+// ems-message-info element or a netapp element holding it.
+func ParseEmsXML(body []byte) (*EmsEvent, error) {
+	var wrapped struct {
+		EmsMessageInfo *emsNotification `xml:"ems-message-info"`
+	}
+	if err := xml.Unmarshal(body, &wrapped); err != nil {
+		return nil, err
+	}
+	n := wrapped.EmsMessageInfo
+	if n == nil {
+		n = &emsNotification{}
+		if err := xml.Unmarshal(body, n); err != nil {
+			return nil, err
+		}
+	}
+	if n.MessageName == "" {
+		return nil, fmt.Errorf("no message-name in EMS notification")
+	}
+	if n.Severity == "" {
+		n.Severity = n.EmsSeverity
+	}
+	return &EmsEvent{
+		Node:        n.Node,
+		SeqNum:      n.SeqNum,
+		Time:        n.Time,
+		MessageName: n.MessageName,
+		Severity:    n.Severity,
+		Event:       n.Event,
+		Source:      n.Source,
+	}, nil
+}
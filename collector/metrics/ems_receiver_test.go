@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestParseEmsSyslog(t *testing.T) {
+	tests := []struct {
+		line  string
+		event *EmsEvent
+	}{
+		{
+			"<185>Oct 18 10:00:00 [cluster1-01:monitor.globalStatus.critical:critical]: Controller failover of cluster1-02 is not possible.",
+			&EmsEvent{Node: "cluster1-01", MessageName: "monitor.globalStatus.critical", Severity: "critical", Event: "Controller failover of cluster1-02 is not possible."},
+		},
+		{
+			"Oct 18 10:00:00 cluster1-01 [cluster1-01: mgwd: wafl.vol.full:alert]: Volume vol1@vserver:svm1 is full.",
+			&EmsEvent{Node: "cluster1-01", Source: "mgwd", MessageName: "wafl.vol.full", Severity: "alert", Event: "Volume vol1@vserver:svm1 is full."},
+		},
+		{
+			"[ cluster1-02 : disk.outOfService : notice ] Drive 1.0.3 is out of service\n",
+			&EmsEvent{Node: "cluster1-02", MessageName: "disk.outOfService", Severity: "notice", Event: "Drive 1.0.3 is out of service"},
+		},
+		{
+			"[cluster1-01:callhome.battery.low:error]:",
+			&EmsEvent{Node: "cluster1-01", MessageName: "callhome.battery.low", Severity: "error"},
+		},
+		{"Oct 18 10:00:00 cluster1-01 sshd[123]: Accepted password for admin", nil},
+		{"[cluster1-01]: no message name", nil},
+		{"", nil},
+	}
+	for _, test := range tests {
+		event, err := ParseEmsSyslog(test.line)
+		if test.event == nil {
+			if err == nil {
+				t.Errorf("ParseEmsSyslog(%q) = %+v; want an error", test.line, *event)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseEmsSyslog(%q) returned error %s", test.line, err)
+			continue
+		}
+		if *event != *test.event {
+			t.Errorf("ParseEmsSyslog(%q) = %+v; want %+v", test.line, *event, *test.event)
+		}
+	}
+}
+
+// TestEmsReceiverCollector checks the events pushed for a target are exported
+// by its collector only, without talking to the NetAPP.
+func TestEmsReceiverCollector(t *testing.T) {
+	RecordEmsEvent("192.0.2.10", &EmsEvent{Node: "cluster1-01", MessageName: "wafl.vol.full", Severity: "ALERT", Time: 1600000000}, false)
+	RecordEmsEvent("192.0.2.11", &EmsEvent{Node: "cluster2-01", MessageName: "wafl.vol.full", Severity: "alert", Time: 1600000000}, false)
+
+	expected := `
+# HELP netapp_ems_received_events_total Number of EMS events pushed to the exporter since it started.
+# TYPE netapp_ems_received_events_total counter
+netapp_ems_received_events_total{cluster="",group="",message_name="wafl.vol.full",node="cluster1-01",severity="alert"} 1
+`
+	if err := testutil.CollectAndCompare(NewEmsReceiverCollector("192.0.2.10"), strings.NewReader(expected), "netapp_ems_received_events_total"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func emsMessageReply(records string) string {
+	return `<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'><results status="passed"><attributes-list>` + records + `</attributes-list></results></netapp>`
+}
+
+func emsDiskEvent(seqNum int) string {
+	return fmt.Sprintf(`<ems-message-info><message-name>disk.outOfService</message-name><node>node-01</node><seq-num>%d</seq-num><severity>alert</severity><time>1593694800</time></ems-message-info>`, seqNum)
+}
+
+// TestScrapeEmsFirstScrape checks the events logged before the first scrape
+// are not counted, so a restart of the exporter doesn't count them again.
+func TestScrapeEmsFirstScrape(t *testing.T) {
+	replies := map[string]string{
+		"ems-message-get-iter": emsMessageReply(emsDiskEvent(100)),
+	}
+	netappClient, stop := newZapiTestClient(replies)
+	defer stop()
+
+	c := scraperCollector{t: t, scraper: NewScrapeEms(false, []string{"alert"}), netappClient: netappClient}
+	if err := testutil.CollectAndCompare(c, strings.NewReader(""), "netapp_ems_events_total"); err != nil {
+		t.Error(err)
+	}
+
+	replies["ems-message-get-iter"] = emsMessageReply(emsDiskEvent(100) + emsDiskEvent(101))
+	expected := `
+# HELP netapp_ems_events_total Number of EMS events seen since the exporter started.
+# TYPE netapp_ems_events_total counter
+netapp_ems_events_total{cluster="",group="",message_name="disk.outOfService",node="node-01",severity="alert"} 1
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected), "netapp_ems_events_total"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,229 @@
+package metrics
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	ExportPolicySubsystem = "export_policy"
+	ExportRuleSubsystem   = "export_rule"
+)
+
+// Metric descriptors.
+var (
+	exportPolicyLabels    = append(variables.BaseLabelNames, "vserver", "policy")
+	exportPolicyRulesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ExportPolicySubsystem, "rules"),
+		"Number of rules of the export policy.",
+		exportPolicyLabels, nil)
+	exportPolicyVolumesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ExportPolicySubsystem, "volumes"),
+		"Number of volumes using the export policy.",
+		exportPolicyLabels, nil)
+	exportPolicyQtreesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ExportPolicySubsystem, "qtrees"),
+		"Number of qtrees with the export policy set, qtrees inheriting the policy of their volume are not counted.",
+		exportPolicyLabels, nil)
+	exportRuleInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ExportRuleSubsystem, "info"),
+		"Information of the export rule, protocol, ro_rule, rw_rule and superuser are comma separated.",
+		append(exportPolicyLabels, "rule_index", "client_match", "protocol", "ro_rule", "rw_rule", "superuser", "anonymous_user"), nil)
+)
+
+// ScrapeExportPolicy collects export policy and export rule info
+type ScrapeExportPolicy struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeExportPolicy) Name() string {
+	return ExportPolicySubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeExportPolicy) Help() string {
+	return "Collect Netapp export policy and rule info;"
+}
+
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeExportPolicy) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 20}
+}
+
+type ExportPolicy struct {
+	Vserver string
+	Policy  string
+}
+
+type ExportRule struct {
+	Vserver       string
+	Policy        string
+	RuleIndex     string
+	ClientMatch   string
+	Protocols     []string
+	RoRule        []string
+	RwRule        []string
+	SuperUser     []string
+	AnonymousUser string
+}
+
+type exportPolicyInfo struct {
+	Vserver    string `xml:"vserver"`
+	PolicyName string `xml:"policy-name"`
+}
+
+type exportRuleInfo struct {
+	VserverName       string   `xml:"vserver-name"`
+	PolicyName        string   `xml:"policy-name"`
+	RuleIndex         string   `xml:"rule-index"`
+	ClientMatch       string   `xml:"client-match"`
+	Protocol          []string `xml:"protocol>access-protocol"`
+	RoRule            []string `xml:"ro-rule>security-flavor"`
+	RwRule            []string `xml:"rw-rule>security-flavor"`
+	SuperUserSecurity []string `xml:"super-user-security>security-flavor"`
+	AnonymousUserId   string   `xml:"anonymous-user-id"`
+}
+
+// volumeExportPolicyInfo is both the desired attributes and the record of
+// volume-get-iter for the export policy of the volumes.
+type volumeExportPolicyInfo struct {
+	XMLName            xml.Name `xml:"volume-attributes"`
+	VolumeIDAttributes struct {
+		OwningVserverName string `xml:"owning-vserver-name"`
+	} `xml:"volume-id-attributes"`
+	VolumeExportAttributes struct {
+		Policy string `xml:"policy"`
+	} `xml:"volume-export-attributes"`
+}
+
+// qtreeExportPolicyInfo is both the desired attributes and the record of
+// qtree-list-iter for the export policy of the qtrees.
+type qtreeExportPolicyInfo struct {
+	XMLName                 xml.Name `xml:"qtree-info"`
+	Vserver                 string   `xml:"vserver"`
+	Qtree                   string   `xml:"qtree"`
+	ExportPolicy            string   `xml:"export-policy"`
+	IsExportPolicyInherited string   `xml:"is-export-policy-inherited"`
+}
+
+// Scrape collects data from  netapp export policy info
+func (ScrapeExportPolicy) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	policies, err := GetExportPolicyData(netappClient)
+	if err != nil {
+		return err
+	}
+	rules, err := GetExportRuleData(netappClient)
+	if err != nil {
+		return err
+	}
+	volumes, err := GetExportPolicyVolumeCount(netappClient)
+	if err != nil {
+		return err
+	}
+	qtrees, err := GetExportPolicyQtreeCount(netappClient)
+	if err != nil {
+		return err
+	}
+
+	ruleCounts := make(map[[2]string]int)
+	for _, rule := range rules {
+		ruleCounts[[2]string{rule.Vserver, rule.Policy}]++
+		ch <- prometheus.MustNewConstMetric(exportRuleInfoDesc, prometheus.GaugeValue, 1, append(variables.BaseLabelValues, rule.Vserver, rule.Policy, rule.RuleIndex, rule.ClientMatch, strings.Join(rule.Protocols, ","), strings.Join(rule.RoRule, ","), strings.Join(rule.RwRule, ","), strings.Join(rule.SuperUser, ","), rule.AnonymousUser)...)
+	}
+	for _, policy := range policies {
+		key := [2]string{policy.Vserver, policy.Policy}
+		exportPolicyLabelValues := append(variables.BaseLabelValues, policy.Vserver, policy.Policy)
+		ch <- prometheus.MustNewConstMetric(exportPolicyRulesDesc, prometheus.GaugeValue, float64(ruleCounts[key]), exportPolicyLabelValues...)
+		ch <- prometheus.MustNewConstMetric(exportPolicyVolumesDesc, prometheus.GaugeValue, float64(volumes[key]), exportPolicyLabelValues...)
+		ch <- prometheus.MustNewConstMetric(exportPolicyQtreesDesc, prometheus.GaugeValue, float64(qtrees[key]), exportPolicyLabelValues...)
+	}
+	return nil
+}
+
+func GetExportPolicyData(netappClient *netapp.Client) (r []*ExportPolicy, err error) {
+
+	var l struct {
+		ExportPolicyInfo []exportPolicyInfo `xml:"export-policy-info"`
+	}
+	if err = zapiGetIter(netappClient, "export-policy-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.ExportPolicyInfo {
+		r = append(r, &ExportPolicy{
+			Vserver: n.Vserver,
+			Policy:  n.PolicyName,
+		})
+	}
+	return
+}
+
+func GetExportRuleData(netappClient *netapp.Client) (r []*ExportRule, err error) {
+
+	var l struct {
+		ExportRuleInfo []exportRuleInfo `xml:"export-rule-info"`
+	}
+	if err = zapiGetIter(netappClient, "export-rule-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.ExportRuleInfo {
+		r = append(r, &ExportRule{
+			Vserver:       n.VserverName,
+			Policy:        n.PolicyName,
+			RuleIndex:     n.RuleIndex,
+			ClientMatch:   n.ClientMatch,
+			Protocols:     n.Protocol,
+			RoRule:        n.RoRule,
+			RwRule:        n.RwRule,
+			SuperUser:     n.SuperUserSecurity,
+			AnonymousUser: n.AnonymousUserId,
+		})
+	}
+	return
+}
+
+// GetExportPolicyVolumeCount returns the number of volumes of each vserver and export policy.
+func GetExportPolicyVolumeCount(netappClient *netapp.Client) (r map[[2]string]int, err error) {
+
+	var l struct {
+		VolumeAttributes []volumeExportPolicyInfo `xml:"volume-attributes"`
+	}
+	if err = zapiGetIterAttributes(netappClient, "volume-get-iter", nil, &volumeExportPolicyInfo{}, &l); err != nil {
+		return
+	}
+
+	r = make(map[[2]string]int)
+	for _, n := range l.VolumeAttributes {
+		if n.VolumeExportAttributes.Policy != "" {
+			r[[2]string{n.VolumeIDAttributes.OwningVserverName, n.VolumeExportAttributes.Policy}]++
+		}
+	}
+	return
+}
+
+// GetExportPolicyQtreeCount returns the number of qtrees of each vserver and
+// export policy, the qtree of the volume itself is left out.
+func GetExportPolicyQtreeCount(netappClient *netapp.Client) (r map[[2]string]int, err error) {
+
+	var l struct {
+		QtreeInfo []qtreeExportPolicyInfo `xml:"qtree-info"`
+	}
+	if err = zapiGetIterAttributes(netappClient, "qtree-list-iter", nil, &qtreeExportPolicyInfo{}, &l); err != nil {
+		return
+	}
+
+	r = make(map[[2]string]int)
+	for _, n := range l.QtreeInfo {
+		if n.Qtree != "" && n.ExportPolicy != "" && n.IsExportPolicyInherited != "true" {
+			r[[2]string{n.Vserver, n.ExportPolicy}]++
+		}
+	}
+	return
+}
@@ -0,0 +1,247 @@
+package metrics
+
+import (
+	"encoding/xml"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	FabricPoolSubsystem = "fabricpool"
+)
+
+// Metric descriptors.
+var (
+	fabricPoolObjectStoreAvailableDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, FabricPoolSubsystem, "object_store_is_available"),
+		"whether the object store attached to the aggr is available.",
+		append(variables.BaseLabelNames, "aggr", "object_store"), nil)
+	fabricPoolObjectStoreInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, FabricPoolSubsystem, "object_store_info"),
+		"Information of the object store, value is always 1.",
+		append(variables.BaseLabelNames, "object_store", "provider_type", "server", "bucket", "ipspace"), nil)
+	fabricPoolCapacityTierUsedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, FabricPoolSubsystem, "capacity_tier_used_bytes"),
+		"Used size of the capacity tier of aggr in bytes.",
+		append(variables.BaseLabelNames, "aggr"), nil)
+	fabricPoolVolumeTieringPolicyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, FabricPoolSubsystem, "volume_tiering_policy"),
+		"Tiering policy of the volume on a FabricPool aggr, value is always 1.",
+		append(variables.BaseLabelNames, "volume", "vserver", "aggr", "tiering_policy"), nil)
+)
+
+// ScrapeFabricPool collects FabricPool object store and tiering info
+type ScrapeFabricPool struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeFabricPool) Name() string {
+	return FabricPoolSubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeFabricPool) Help() string {
+	return "Collect Netapp FabricPool object store and tiering info;"
+}
+
+// Version of ZAPI from which the Scraper is available, FabricPool is
+// available from ONTAP 9.2.
+func (ScrapeFabricPool) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 120}
+}
+
+type AggrObjectStore struct {
+	Aggregate       string
+	ObjectStoreName string
+	Availability    string
+}
+
+type ObjectStoreConfig struct {
+	ObjectStoreName string
+	ProviderType    string
+	Server          string
+	S3Name          string
+	Ipspace         string
+}
+
+type AggrCapacityTier struct {
+	Name             string
+	CapacityTierUsed string
+}
+
+type VolumeTiering struct {
+	Name          string
+	Vserver       string
+	Aggr          string
+	TieringPolicy string
+}
+
+type aggrObjectStoreInfo struct {
+	Aggregate               string `xml:"aggregate"`
+	ObjectStoreName         string `xml:"object-store-name"`
+	ObjectStoreAvailability string `xml:"object-store-availability"`
+}
+
+type aggrObjectStoreConfigInfo struct {
+	ObjectStoreName string `xml:"object-store-name"`
+	ProviderType    string `xml:"provider-type"`
+	Server          string `xml:"server"`
+	S3Name          string `xml:"s3-name"`
+	Ipspace         string `xml:"ipspace"`
+}
+
+// aggrCapacityTierInfo is both the desired attributes and the record of aggr-get-iter.
+type aggrCapacityTierInfo struct {
+	XMLName             xml.Name `xml:"aggr-attributes"`
+	AggregateName       string   `xml:"aggregate-name"`
+	AggrSpaceAttributes struct {
+		CapacityTierUsed string `xml:"capacity-tier-used"`
+	} `xml:"aggr-space-attributes"`
+}
+
+// volumeTieringInfo is both the desired attributes and the record of volume-get-iter.
+type volumeTieringInfo struct {
+	XMLName            xml.Name `xml:"volume-attributes"`
+	VolumeIDAttributes struct {
+		Name                    string `xml:"name"`
+		OwningVserverName       string `xml:"owning-vserver-name"`
+		ContainingAggregateName string `xml:"containing-aggregate-name"`
+	} `xml:"volume-id-attributes"`
+	VolumeCompAggrAttributes struct {
+		TieringPolicy string `xml:"tiering-policy"`
+	} `xml:"volume-comp-aggr-attributes"`
+}
+
+// Scrape collects data from  netapp FabricPool info
+func (ScrapeFabricPool) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	objectStores, err := GetAggrObjectStoreData(netappClient)
+	if err != nil {
+		return err
+	}
+	fabricPoolAggrs := make(map[string]bool)
+	for _, objectStore := range objectStores {
+		fabricPoolAggrs[objectStore.Aggregate] = true
+		ch <- prometheus.MustNewConstMetric(fabricPoolObjectStoreAvailableDesc, prometheus.GaugeValue, utils.BoolToFloat64(objectStore.Availability == "available"),
+			append(variables.BaseLabelValues, objectStore.Aggregate, objectStore.ObjectStoreName)...)
+	}
+
+	objectStoreConfigs, err := GetObjectStoreConfigData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, config := range objectStoreConfigs {
+		ch <- prometheus.MustNewConstMetric(fabricPoolObjectStoreInfoDesc, prometheus.GaugeValue, 1,
+			append(variables.BaseLabelValues, config.ObjectStoreName, config.ProviderType, config.Server, config.S3Name, config.Ipspace)...)
+	}
+	if len(fabricPoolAggrs) == 0 {
+		return nil
+	}
+
+	aggrs, err := GetAggrCapacityTierData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, aggr := range aggrs {
+		if !fabricPoolAggrs[aggr.Name] {
+			continue
+		}
+		if capacityTierUsed, ok := utils.ParseStatus(aggr.CapacityTierUsed); ok {
+			ch <- prometheus.MustNewConstMetric(fabricPoolCapacityTierUsedDesc, prometheus.GaugeValue, capacityTierUsed, append(variables.BaseLabelValues, aggr.Name)...)
+		}
+	}
+
+	volumes, err := GetVolumeTieringData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, volume := range volumes {
+		if !fabricPoolAggrs[volume.Aggr] || volume.TieringPolicy == "" {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(fabricPoolVolumeTieringPolicyDesc, prometheus.GaugeValue, 1,
+			append(variables.BaseLabelValues, volume.Name, volume.Vserver, volume.Aggr, volume.TieringPolicy)...)
+	}
+	return nil
+}
+
+func GetAggrObjectStoreData(netappClient *netapp.Client) (r []*AggrObjectStore, err error) {
+
+	var l struct {
+		AggrObjectStoreInfo []aggrObjectStoreInfo `xml:"aggr-object-store-info"`
+	}
+	if err = zapiGetIter(netappClient, "aggr-object-store-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.AggrObjectStoreInfo {
+		r = append(r, &AggrObjectStore{
+			Aggregate:       n.Aggregate,
+			ObjectStoreName: n.ObjectStoreName,
+			Availability:    n.ObjectStoreAvailability,
+		})
+	}
+	return
+}
+
+func GetObjectStoreConfigData(netappClient *netapp.Client) (r []*ObjectStoreConfig, err error) {
+
+	var l struct {
+		AggrObjectStoreConfigInfo []aggrObjectStoreConfigInfo `xml:"aggr-object-store-config-info"`
+	}
+	if err = zapiGetIter(netappClient, "aggr-object-store-config-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.AggrObjectStoreConfigInfo {
+		r = append(r, &ObjectStoreConfig{
+			ObjectStoreName: n.ObjectStoreName,
+			ProviderType:    n.ProviderType,
+			Server:          n.Server,
+			S3Name:          n.S3Name,
+			Ipspace:         n.Ipspace,
+		})
+	}
+	return
+}
+
+func GetAggrCapacityTierData(netappClient *netapp.Client) (r []*AggrCapacityTier, err error) {
+
+	var l struct {
+		AggrAttributes []aggrCapacityTierInfo `xml:"aggr-attributes"`
+	}
+	if err = zapiGetIterAttributes(netappClient, "aggr-get-iter", nil, &aggrCapacityTierInfo{}, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.AggrAttributes {
+		r = append(r, &AggrCapacityTier{
+			Name:             n.AggregateName,
+			CapacityTierUsed: n.AggrSpaceAttributes.CapacityTierUsed,
+		})
+	}
+	return
+}
+
+func GetVolumeTieringData(netappClient *netapp.Client) (r []*VolumeTiering, err error) {
+
+	var l struct {
+		VolumeAttributes []volumeTieringInfo `xml:"volume-attributes"`
+	}
+	if err = zapiGetIterAttributes(netappClient, "volume-get-iter", nil, &volumeTieringInfo{}, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.VolumeAttributes {
+		r = append(r, &VolumeTiering{
+			Name:          n.VolumeIDAttributes.Name,
+			Vserver:       n.VolumeIDAttributes.OwningVserverName,
+			Aggr:          n.VolumeIDAttributes.ContainingAggregateName,
+			TieringPolicy: n.VolumeCompAggrAttributes.TieringPolicy,
+		})
+	}
+	return
+}
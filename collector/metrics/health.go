@@ -0,0 +1,162 @@
+package metrics
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	HealthSubsystem = "health"
+)
+
+// Metric descriptors.
+var (
+	healthStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, HealthSubsystem, "status"),
+		"Overall health of the cluster reported by the health monitors, 1(ok), 0(degraded), 2(ok-with-suppressed), or 3(unreachable).",
+		variables.BaseLabelNames, nil)
+	healthAlertLabels = append(variables.BaseLabelNames, "alert_id", "monitor", "node", "probable_cause", "severity", "resource")
+	healthAlertDesc   = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, HealthSubsystem, "alert"),
+		"Active alert of the health monitors, it is gone once the alert clears.",
+		healthAlertLabels, nil)
+	healthAlertTimestampDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, HealthSubsystem, "alert_timestamp_seconds"),
+		"Time the active alert was raised.",
+		healthAlertLabels, nil)
+)
+
+var healthStatus = map[string]float64{
+	"degraded":           0,
+	"ok":                 1,
+	"ok-with-suppressed": 2,
+	"unreachable":        3,
+}
+
+// ScrapeHealth collects the cluster health status and the active health alerts
+type ScrapeHealth struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeHealth) Name() string {
+	return HealthSubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeHealth) Help() string {
+	return "Collect Netapp cluster health status and alerts;"
+}
+
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeHealth) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 21}
+}
+
+type HealthAlert struct {
+	AlertId        string
+	Monitor        string
+	Node           string
+	ProbableCause  string
+	Severity       string
+	Resource       string
+	IndicationTime int
+}
+
+type diagnosisStatusGetResponse struct {
+	XMLName xml.Name `xml:"netapp"`
+	Results struct {
+		Status string `xml:"attributes>diagnosis-status-info>status"`
+	} `xml:"results"`
+}
+
+// Scrape collects data from  netapp health monitors
+func (ScrapeHealth) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	status, err := GetHealthStatus(netappClient)
+	if err != nil {
+		return err
+	}
+	if value, ok := healthStatus[status]; ok {
+		ch <- prometheus.MustNewConstMetric(healthStatusDesc, prometheus.GaugeValue, value, variables.BaseLabelValues...)
+	}
+
+	alerts, err := GetHealthAlertData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, alert := range alerts {
+		alertLabelValues := append(variables.BaseLabelValues, alert.AlertId, alert.Monitor, alert.Node, alert.ProbableCause, alert.Severity, alert.Resource)
+		ch <- prometheus.MustNewConstMetric(healthAlertDesc, prometheus.GaugeValue, 1, alertLabelValues...)
+		if alert.IndicationTime > 0 {
+			ch <- prometheus.MustNewConstMetric(healthAlertTimestampDesc, prometheus.GaugeValue, float64(alert.IndicationTime), alertLabelValues...)
+		}
+	}
+	return nil
+}
+
+// GetHealthStatus returns the overall status of the health monitors via diagnosis-status-get.
+func GetHealthStatus(netappClient *netapp.Client) (string, error) {
+
+	api := struct {
+		XMLName xml.Name `xml:"diagnosis-status-get"`
+	}{}
+	var res diagnosisStatusGetResponse
+	if err := zapiInvoke(netappClient, &api, &res); err != nil {
+		return "", fmt.Errorf("diagnosis-status-get: %s", err)
+	}
+	return res.Results.Status, nil
+}
+
+func GetHealthAlertData(netappClient *netapp.Client) (r []*HealthAlert, err error) {
+	opts := &netapp.DiagnosisOptions{
+		MaxRecords: zapiMaxRecords,
+	}
+
+	l, err := getDiagnosisAlertList(netappClient, opts)
+	if err != nil {
+		return
+	}
+
+	for _, n := range l {
+		r = append(r, &HealthAlert{
+			AlertId:        n.AlertId,
+			Monitor:        n.Monitor,
+			Node:           n.Node,
+			ProbableCause:  n.ProbableCause,
+			Severity:       n.PerceivedSeverity,
+			Resource:       n.AlertingResourceName,
+			IndicationTime: n.IndicationTime,
+		})
+	}
+	return
+}
+
+func getDiagnosisAlertList(netappClient *netapp.Client, opts *netapp.DiagnosisOptions) (r []netapp.DiagnosisAlertInfo, err error) {
+
+	var pages []*netapp.DiagnosisListResponse
+	handler := func(r netapp.DiagnosisAlertPagesResponse) bool {
+		if r.Error != nil {
+			err = r.Error
+			return false
+		}
+		if !r.Response.Results.Passed() {
+			err = fmt.Errorf("diagnosis-alert-get-iter: %s (errno %d)", r.Response.Results.Reason, r.Response.Results.ErrorNo)
+			return false
+		}
+		pages = append(pages, r.Response)
+		return true
+	}
+
+	netappClient.Diagnosis.DiagnosisAlertGetAll(opts, handler)
+
+	for _, p := range pages {
+		r = append(r, p.Results.AttributesList.DiagnosisAttributes...)
+	}
+
+	return
+}
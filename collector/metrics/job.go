@@ -0,0 +1,246 @@
+package metrics
+
+import (
+	"encoding/xml"
+	"regexp"
+	"strconv"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	JobSubsystem        = "job"
+	VolumeMoveSubsystem = "volume_move"
+)
+
+// Metric descriptors.
+var (
+	volumeMoveLabels   = append(variables.BaseLabelNames, "volume", "vserver", "source_aggr", "destination_aggr")
+	volumeMoveInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, VolumeMoveSubsystem, "info"),
+		"Information of the volume move, phase is one of initializing, replicating, cutover, cutover_hard_deferred, cutover_soft_deferred, finishing, completed, aborting, etc.; state is one of healthy, warning, alert, failed or done.",
+		append(volumeMoveLabels, "source_node", "destination_node", "phase", "state"), nil)
+	volumeMovePercentCompleteDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, VolumeMoveSubsystem, "percent_complete"),
+		"Percent complete of the volume move.",
+		volumeMoveLabels, nil)
+	volumeMoveEstimatedCompletionDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, VolumeMoveSubsystem, "estimated_completion_timestamp_seconds"),
+		"Estimated time of completion of the volume move.",
+		volumeMoveLabels, nil)
+	volumeMoveCutoverAttemptsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, VolumeMoveSubsystem, "cutover_attempts"),
+		"Number of cutover attempts made by the volume move.",
+		volumeMoveLabels, nil)
+	volumeMoveCutoverAttemptsMaxDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, VolumeMoveSubsystem, "cutover_attempts_max"),
+		"Number of cutover attempts allowed to the volume move.",
+		volumeMoveLabels, nil)
+	volumeMoveBytesRemainingDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, VolumeMoveSubsystem, "bytes_remaining"),
+		"Bytes left to be replicated by the volume move.",
+		volumeMoveLabels, nil)
+
+	jobLabels        = append(variables.BaseLabelNames, "job_id", "name", "description", "node", "vserver")
+	jobStartTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, JobSubsystem, "start_timestamp_seconds"),
+		"Start time of the running job.",
+		jobLabels, nil)
+	jobPercentCompleteDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, JobSubsystem, "percent_complete"),
+		"Percent complete of the running job, when reported in its progress.",
+		jobLabels, nil)
+)
+
+// jobPercentRegexp finds the percentage in the progress of a job, e.g.
+// "Clone split is 45% complete".
+var jobPercentRegexp = regexp.MustCompile(`([0-9]+(\.[0-9]+)?)\s*%`)
+
+// ScrapeJob collects volume move and running job info
+type ScrapeJob struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeJob) Name() string {
+	return JobSubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeJob) Help() string {
+	return "Collect Netapp volume move and running job info;"
+}
+
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeJob) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 20}
+}
+
+type VolumeMove struct {
+	Volume                  string
+	Vserver                 string
+	SourceAggregate         string
+	DestinationAggregate    string
+	SourceNode              string
+	DestinationNode         string
+	Phase                   string
+	State                   string
+	PercentComplete         *float64
+	EstimatedCompletionTime int64
+	CutoverAttemptedCount   *float64
+	CutoverAttempts         *float64
+	BytesRemaining          *float64
+}
+
+type Job struct {
+	JobId           string
+	Name            string
+	Description     string
+	Node            string
+	Vserver         string
+	State           string
+	Progress        string
+	StartTime       int64
+	PercentComplete *float64
+}
+
+type volumeMoveInfo struct {
+	Volume                  string   `xml:"volume"`
+	Vserver                 string   `xml:"vserver"`
+	SourceAggregate         string   `xml:"source-aggregate"`
+	DestinationAggregate    string   `xml:"destination-aggregate"`
+	SourceNode              string   `xml:"source-node"`
+	DestinationNode         string   `xml:"destination-node"`
+	Phase                   string   `xml:"phase"`
+	State                   string   `xml:"state"`
+	PercentComplete         *float64 `xml:"percent-complete"`
+	EstimatedCompletionTime int64    `xml:"estimated-completion-time"`
+	CutoverAttemptedCount   *float64 `xml:"cutover-attempted-count"`
+	CutoverAttempts         *float64 `xml:"cutover-attempts"`
+	BytesRemaining          *float64 `xml:"bytes-remaining"`
+}
+
+type jobInfo struct {
+	XMLName        xml.Name `xml:"job-info"`
+	JobId          string   `xml:"job-id,omitempty"`
+	JobName        string   `xml:"job-name,omitempty"`
+	JobDescription string   `xml:"job-description,omitempty"`
+	JobNode        string   `xml:"job-node,omitempty"`
+	JobVserver     string   `xml:"job-vserver,omitempty"`
+	JobState       string   `xml:"job-state,omitempty"`
+	JobProgress    string   `xml:"job-progress,omitempty"`
+	JobStartTime   int64    `xml:"job-start-time,omitempty"`
+}
+
+// Scrape collects data from  netapp volume move and job info
+func (ScrapeJob) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	volumeMoves, err := GetVolumeMoveData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, volumeMove := range volumeMoves {
+		volumeMoveLabelValues := append(variables.BaseLabelValues, volumeMove.Volume, volumeMove.Vserver, volumeMove.SourceAggregate, volumeMove.DestinationAggregate)
+		ch <- prometheus.MustNewConstMetric(volumeMoveInfoDesc, prometheus.GaugeValue, 1, append(volumeMoveLabelValues, volumeMove.SourceNode, volumeMove.DestinationNode, volumeMove.Phase, volumeMove.State)...)
+		if volumeMove.PercentComplete != nil {
+			ch <- prometheus.MustNewConstMetric(volumeMovePercentCompleteDesc, prometheus.GaugeValue, *volumeMove.PercentComplete, volumeMoveLabelValues...)
+		}
+		if volumeMove.EstimatedCompletionTime > 0 {
+			ch <- prometheus.MustNewConstMetric(volumeMoveEstimatedCompletionDesc, prometheus.GaugeValue, float64(volumeMove.EstimatedCompletionTime), volumeMoveLabelValues...)
+		}
+		if volumeMove.CutoverAttemptedCount != nil {
+			ch <- prometheus.MustNewConstMetric(volumeMoveCutoverAttemptsDesc, prometheus.GaugeValue, *volumeMove.CutoverAttemptedCount, volumeMoveLabelValues...)
+		}
+		if volumeMove.CutoverAttempts != nil {
+			ch <- prometheus.MustNewConstMetric(volumeMoveCutoverAttemptsMaxDesc, prometheus.GaugeValue, *volumeMove.CutoverAttempts, volumeMoveLabelValues...)
+		}
+		if volumeMove.BytesRemaining != nil {
+			ch <- prometheus.MustNewConstMetric(volumeMoveBytesRemainingDesc, prometheus.GaugeValue, *volumeMove.BytesRemaining, volumeMoveLabelValues...)
+		}
+	}
+
+	jobs, err := GetRunningJobData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		jobLabelValues := append(variables.BaseLabelValues, job.JobId, job.Name, job.Description, job.Node, job.Vserver)
+		if job.StartTime > 0 {
+			ch <- prometheus.MustNewConstMetric(jobStartTimeDesc, prometheus.GaugeValue, float64(job.StartTime), jobLabelValues...)
+		}
+		if job.PercentComplete != nil {
+			ch <- prometheus.MustNewConstMetric(jobPercentCompleteDesc, prometheus.GaugeValue, *job.PercentComplete, jobLabelValues...)
+		}
+	}
+	return nil
+}
+
+func GetVolumeMoveData(netappClient *netapp.Client) (r []*VolumeMove, err error) {
+
+	var l struct {
+		VolumeMoveInfo []volumeMoveInfo `xml:"volume-move-info"`
+	}
+	if err = zapiGetIter(netappClient, "volume-move-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.VolumeMoveInfo {
+		r = append(r, &VolumeMove{
+			Volume:                  n.Volume,
+			Vserver:                 n.Vserver,
+			SourceAggregate:         n.SourceAggregate,
+			DestinationAggregate:    n.DestinationAggregate,
+			SourceNode:              n.SourceNode,
+			DestinationNode:         n.DestinationNode,
+			Phase:                   n.Phase,
+			State:                   n.State,
+			PercentComplete:         n.PercentComplete,
+			EstimatedCompletionTime: n.EstimatedCompletionTime,
+			CutoverAttemptedCount:   n.CutoverAttemptedCount,
+			CutoverAttempts:         n.CutoverAttempts,
+			BytesRemaining:          n.BytesRemaining,
+		})
+	}
+	return
+}
+
+// GetRunningJobData returns the jobs in the running state, such as clone
+// splits or aggregate relocations.
+func GetRunningJobData(netappClient *netapp.Client) (r []*Job, err error) {
+
+	var l struct {
+		JobInfo []jobInfo `xml:"job-info"`
+	}
+	if err = zapiGetIter(netappClient, "job-get-iter", &jobInfo{JobState: "running"}, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.JobInfo {
+		r = append(r, &Job{
+			JobId:           n.JobId,
+			Name:            n.JobName,
+			Description:     n.JobDescription,
+			Node:            n.JobNode,
+			Vserver:         n.JobVserver,
+			State:           n.JobState,
+			Progress:        n.JobProgress,
+			StartTime:       n.JobStartTime,
+			PercentComplete: parseJobPercent(n.JobProgress),
+		})
+	}
+	return
+}
+
+func parseJobPercent(progress string) *float64 {
+	match := jobPercentRegexp.FindStringSubmatch(progress)
+	if match == nil {
+		return nil
+	}
+	percent, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return nil
+	}
+	return &percent
+}
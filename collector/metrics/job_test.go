@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestParseJobPercent(t *testing.T) {
+	tests := []struct {
+		progress string
+		percent  float64
+		ok       bool
+	}{
+		{"Clone split is 45% complete", 45, true},
+		{"Volume move is 12.5 % complete", 12.5, true},
+		{"100%", 100, true},
+		{"0% complete", 0, true},
+		{"Phase 2 of 3: 60% complete", 60, true},
+		{"Waiting for cutover", 0, false},
+		{"", 0, false},
+		{"%", 0, false},
+	}
+	for _, test := range tests {
+		percent := parseJobPercent(test.progress)
+		if (percent != nil) != test.ok || (percent != nil && *percent != test.percent) {
+			got := "nil"
+			if percent != nil {
+				got = strconv.FormatFloat(*percent, 'f', -1, 64)
+			}
+			t.Errorf("parseJobPercent(%q) = %s; want %v (%v)", test.progress, got, test.percent, test.ok)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package metrics
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	LicenseSubsystem = "license"
+)
+
+// Metric descriptors.
+var (
+	licenseLabels   = append(variables.BaseLabelNames, "package", "owner", "type", "serial_number")
+	licenseInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, LicenseSubsystem, "info"),
+		"Information of the license, type is one of license, site, demo, temporary, master, etc.",
+		append(licenseLabels, "description"), nil)
+	licenseExpiryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, LicenseSubsystem, "expiry_timestamp_seconds"),
+		"Expiration time of the license, only exported for licenses which expire.",
+		licenseLabels, nil)
+	licenseNodeLicensedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, LicenseSubsystem, "node_is_licensed"),
+		"whether the node holds an unexpired node-locked or cluster-wide license of the package.",
+		append(variables.BaseLabelNames, "package", "node"), nil)
+	licenseEntitlementRiskDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, LicenseSubsystem, "entitlement_risk"),
+		"Entitlement risk of the package, 0(low), 1(medium), 2(high), or 3(unlicensed).",
+		append(variables.BaseLabelNames, "package", "node"), nil)
+)
+
+var licenseEntitlementRisk = map[string]float64{
+	"low":        0,
+	"medium":     1,
+	"high":       2,
+	"unlicensed": 3,
+}
+
+// ScrapeLicense collects license and entitlement risk info
+type ScrapeLicense struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeLicense) Name() string {
+	return LicenseSubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeLicense) Help() string {
+	return "Collect Netapp license and entitlement risk info;"
+}
+
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeLicense) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 21}
+}
+
+type License struct {
+	Package        string
+	Owner          string
+	Type           string
+	Description    string
+	SerialNumber   string
+	ExpirationTime int64
+}
+
+type LicenseEntitlementRisk struct {
+	Package string
+	Node    string
+	Risk    string
+}
+
+type licenseV2Info struct {
+	Package        string `xml:"package"`
+	Owner          string `xml:"owner"`
+	Type           string `xml:"type"`
+	Description    string `xml:"description"`
+	SerialNumber   string `xml:"serial-number"`
+	ExpirationTime int64  `xml:"expiration-time"`
+}
+
+type licenseV2ListInfoResponse struct {
+	XMLName xml.Name `xml:"netapp"`
+	Results struct {
+		Licenses []licenseV2Info `xml:"licenses>license-v2-info"`
+	} `xml:"results"`
+}
+
+type licenseV2EntitlementRiskInfo struct {
+	Package  string `xml:"package"`
+	NodeName string `xml:"node-name"`
+	Risk     string `xml:"risk"`
+}
+
+// Scrape collects data from  netapp license info
+func (ScrapeLicense) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	licenses, err := GetLicenseData(netappClient)
+	if err != nil {
+		return err
+	}
+	now := time.Now().Unix()
+	packages := make(map[string][]*License)
+	for _, license := range licenses {
+		licenseLabelValues := append(variables.BaseLabelValues, license.Package, license.Owner, license.Type, license.SerialNumber)
+		ch <- prometheus.MustNewConstMetric(licenseInfoDesc, prometheus.GaugeValue, 1, append(licenseLabelValues, license.Description)...)
+		if license.ExpirationTime > 0 {
+			ch <- prometheus.MustNewConstMetric(licenseExpiryDesc, prometheus.GaugeValue, float64(license.ExpirationTime), licenseLabelValues...)
+		}
+		if license.ExpirationTime == 0 || license.ExpirationTime > now {
+			packages[license.Package] = append(packages[license.Package], license)
+		}
+	}
+
+	// node-locked licenses are owned by the node, the others by the cluster.
+	nodes := make(map[string]bool)
+	for _, node := range GetNodeData(netappClient) {
+		nodes[node.Name] = true
+	}
+	for pkg, pkgLicenses := range packages {
+		for node := range nodes {
+			licensed := false
+			for _, license := range pkgLicenses {
+				if license.Owner == node || !nodes[license.Owner] {
+					licensed = true
+					break
+				}
+			}
+			ch <- prometheus.MustNewConstMetric(licenseNodeLicensedDesc, prometheus.GaugeValue, utils.BoolToFloat64(licensed), append(variables.BaseLabelValues, pkg, node)...)
+		}
+	}
+
+	if zapiSupports(netappClient, utils.ZapiVersion{Major: 1, Minor: 30}) {
+		risks, err := GetLicenseEntitlementRiskData(netappClient)
+		if err != nil {
+			return err
+		}
+		for _, risk := range risks {
+			if value, ok := licenseEntitlementRisk[risk.Risk]; ok {
+				ch <- prometheus.MustNewConstMetric(licenseEntitlementRiskDesc, prometheus.GaugeValue, value, append(variables.BaseLabelValues, risk.Package, risk.Node)...)
+			}
+		}
+	}
+	return nil
+}
+
+func GetLicenseData(netappClient *netapp.Client) (r []*License, err error) {
+
+	api := struct {
+		XMLName xml.Name `xml:"license-v2-list-info"`
+	}{}
+	var res licenseV2ListInfoResponse
+	if err = zapiInvoke(netappClient, &api, &res); err != nil {
+		err = fmt.Errorf("license-v2-list-info: %s", err)
+		return
+	}
+
+	for _, n := range res.Results.Licenses {
+		r = append(r, &License{
+			Package:        n.Package,
+			Owner:          n.Owner,
+			Type:           n.Type,
+			Description:    n.Description,
+			SerialNumber:   n.SerialNumber,
+			ExpirationTime: n.ExpirationTime,
+		})
+	}
+	return
+}
+
+func GetLicenseEntitlementRiskData(netappClient *netapp.Client) (r []*LicenseEntitlementRisk, err error) {
+
+	var l struct {
+		LicenseV2EntitlementRiskInfo []licenseV2EntitlementRiskInfo `xml:"license-v2-entitlement-risk-info"`
+	}
+	if err = zapiGetIter(netappClient, "license-v2-entitlement-risk-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.LicenseV2EntitlementRiskInfo {
+		r = append(r, &LicenseEntitlementRisk{
+			Package: n.Package,
+			Node:    n.NodeName,
+			Risk:    n.Risk,
+		})
+	}
+	return
+}
@@ -52,6 +52,11 @@ func (ScrapeLun) Help() string {
 	return "Collect Netapp Lun info;"
 }
 
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeLun) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 20}
+}
+
 type Lun struct {
 	Node     string
 	Volume   string
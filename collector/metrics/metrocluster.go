@@ -0,0 +1,316 @@
+package metrics
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	MetroclusterSubsystem = "metrocluster"
+)
+
+// Metric descriptors.
+var (
+	metroclusterLabels   = append(variables.BaseLabelNames, "site", "cluster_name")
+	metroclusterInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, MetroclusterSubsystem, "info"),
+		"Information of the MetroCluster configuration of the local and the remote site.",
+		append(metroclusterLabels, "configuration_type", "configuration_state", "mode"), nil)
+	metroclusterConfiguredDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, MetroclusterSubsystem, "is_configured"),
+		"whether the MetroCluster configuration of the site is complete.",
+		metroclusterLabels, nil)
+	metroclusterModeNormalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, MetroclusterSubsystem, "is_mode_normal"),
+		"whether the site is in normal mode, i.e. not switched over.",
+		metroclusterLabels, nil)
+
+	metroclusterNodeLabels   = append(variables.BaseLabelNames, "node", "cluster_name", "dr_group_id")
+	metroclusterNodeInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, MetroclusterSubsystem, "node_info"),
+		"Information of the node in its DR group.",
+		append(metroclusterNodeLabels, "dr_partner", "dr_auxiliary", "ha_partner", "configuration_state", "dr_mirroring_state"), nil)
+	metroclusterNodeConfiguredDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, MetroclusterSubsystem, "node_is_configured"),
+		"whether the node is configured for MetroCluster.",
+		metroclusterNodeLabels, nil)
+	metroclusterNodeMirroringDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, MetroclusterSubsystem, "node_is_dr_mirroring_enabled"),
+		"whether the mirroring of the node to its DR partner is enabled.",
+		metroclusterNodeLabels, nil)
+
+	metroclusterCheckLabels     = append(variables.BaseLabelNames, "component")
+	metroclusterCheckResultDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, MetroclusterSubsystem, "check_result"),
+		"Result of the last MetroCluster check of the component, 1(ok), 0(error), 2(warning), 3(not_run), or 4(not_applicable).",
+		metroclusterCheckLabels, nil)
+	metroclusterCheckTimestampDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, MetroclusterSubsystem, "check_timestamp_seconds"),
+		"Time of the last MetroCluster check of the component.",
+		metroclusterCheckLabels, nil)
+
+	metroclusterConnectionLabels        = append(variables.BaseLabelNames, "node", "home_port", "relationship_type", "partner", "source_address", "destination_address")
+	metroclusterConnectionCompletedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, MetroclusterSubsystem, "connection_is_completed"),
+		"whether the connection of the node to its HA, DR or auxiliary partner is completed.",
+		metroclusterConnectionLabels, nil)
+)
+
+var metroclusterCheckResult = map[string]float64{
+	"error":          0,
+	"ok":             1,
+	"warning":        2,
+	"not_run":        3,
+	"not_applicable": 4,
+}
+
+// ScrapeMetrocluster collects MetroCluster configuration, check and connection info
+type ScrapeMetrocluster struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeMetrocluster) Name() string {
+	return MetroclusterSubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeMetrocluster) Help() string {
+	return "Collect Netapp MetroCluster info;"
+}
+
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeMetrocluster) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 30}
+}
+
+type Metrocluster struct {
+	ConfigurationType        string
+	LocalClusterName         string
+	LocalConfigurationState  string
+	LocalMode                string
+	RemoteClusterName        string
+	RemoteConfigurationState string
+	RemoteMode               string
+}
+
+type MetroclusterNode struct {
+	Node               string
+	ClusterName        string
+	DrGroupId          string
+	DrPartner          string
+	DrAuxiliary        string
+	HaPartner          string
+	ConfigurationState string
+	DrMirroringState   string
+}
+
+type MetroclusterCheck struct {
+	Component string
+	Result    string
+	Timestamp int64
+}
+
+type MetroclusterConnection struct {
+	Node               string
+	HomePort           string
+	RelationshipType   string
+	Partner            string
+	SourceAddress      string
+	DestinationAddress string
+	ConfigState        string
+}
+
+type metroclusterGetResponse struct {
+	XMLName xml.Name `xml:"netapp"`
+	Results struct {
+		MetroclusterInfo struct {
+			ConfigurationType        string `xml:"configuration-type"`
+			LocalClusterName         string `xml:"local-cluster-name"`
+			LocalConfigurationState  string `xml:"local-configuration-state"`
+			LocalMode                string `xml:"local-mode"`
+			RemoteClusterName        string `xml:"remote-cluster-name"`
+			RemoteConfigurationState string `xml:"remote-configuration-state"`
+			RemoteMode               string `xml:"remote-mode"`
+		} `xml:"attributes>metrocluster-info"`
+	} `xml:"results"`
+}
+
+type metroclusterNodeInfo struct {
+	NodeName           string `xml:"node-name"`
+	ClusterName        string `xml:"cluster-name"`
+	DrGroupId          string `xml:"dr-group-id"`
+	DrPartnerName      string `xml:"dr-partner-name"`
+	DrAuxiliaryName    string `xml:"dr-auxiliary-name"`
+	HaPartnerName      string `xml:"ha-partner-name"`
+	ConfigurationState string `xml:"configuration-state"`
+	DrMirroringState   string `xml:"dr-mirroring-state"`
+}
+
+type metroclusterCheckInfo struct {
+	Component string `xml:"component"`
+	Result    string `xml:"result"`
+	Timestamp int64  `xml:"timestamp"`
+}
+
+type metroclusterCheckConnectionInfo struct {
+	NodeName           string `xml:"node-name"`
+	HomePort           string `xml:"home-port"`
+	RelationshipType   string `xml:"relationship-type"`
+	PartnerName        string `xml:"partner-name"`
+	SourceAddress      string `xml:"source-address"`
+	DestinationAddress string `xml:"destination-address"`
+	ConfigState        string `xml:"config-state"`
+}
+
+// Scrape collects data from  netapp MetroCluster info
+func (ScrapeMetrocluster) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	metrocluster, err := GetMetroclusterData(netappClient)
+	if err != nil {
+		return err
+	}
+	// the other MetroCluster APIs fail on a cluster which is not part of one.
+	if metrocluster.LocalConfigurationState == "" || metrocluster.LocalConfigurationState == "not_configured" {
+		return nil
+	}
+	sites := []struct{ site, clusterName, state, mode string }{
+		{"local", metrocluster.LocalClusterName, metrocluster.LocalConfigurationState, metrocluster.LocalMode},
+		{"remote", metrocluster.RemoteClusterName, metrocluster.RemoteConfigurationState, metrocluster.RemoteMode},
+	}
+	for _, site := range sites {
+		metroclusterLabelValues := append(variables.BaseLabelValues, site.site, site.clusterName)
+		ch <- prometheus.MustNewConstMetric(metroclusterInfoDesc, prometheus.GaugeValue, 1, append(metroclusterLabelValues, metrocluster.ConfigurationType, site.state, site.mode)...)
+		ch <- prometheus.MustNewConstMetric(metroclusterConfiguredDesc, prometheus.GaugeValue, utils.BoolToFloat64(site.state == "configured"), metroclusterLabelValues...)
+		if site.mode != "" {
+			ch <- prometheus.MustNewConstMetric(metroclusterModeNormalDesc, prometheus.GaugeValue, utils.BoolToFloat64(site.mode == "normal"), metroclusterLabelValues...)
+		}
+	}
+
+	nodes, err := GetMetroclusterNodeData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		nodeLabelValues := append(variables.BaseLabelValues, node.Node, node.ClusterName, node.DrGroupId)
+		ch <- prometheus.MustNewConstMetric(metroclusterNodeInfoDesc, prometheus.GaugeValue, 1, append(nodeLabelValues, node.DrPartner, node.DrAuxiliary, node.HaPartner, node.ConfigurationState, node.DrMirroringState)...)
+		ch <- prometheus.MustNewConstMetric(metroclusterNodeConfiguredDesc, prometheus.GaugeValue, utils.BoolToFloat64(node.ConfigurationState == "configured"), nodeLabelValues...)
+		ch <- prometheus.MustNewConstMetric(metroclusterNodeMirroringDesc, prometheus.GaugeValue, utils.BoolToFloat64(node.DrMirroringState == "enabled"), nodeLabelValues...)
+	}
+
+	checks, err := GetMetroclusterCheckData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, check := range checks {
+		checkLabelValues := append(variables.BaseLabelValues, check.Component)
+		if value, ok := metroclusterCheckResult[strings.Replace(check.Result, "-", "_", -1)]; ok {
+			ch <- prometheus.MustNewConstMetric(metroclusterCheckResultDesc, prometheus.GaugeValue, value, checkLabelValues...)
+		}
+		if check.Timestamp > 0 {
+			ch <- prometheus.MustNewConstMetric(metroclusterCheckTimestampDesc, prometheus.GaugeValue, float64(check.Timestamp), checkLabelValues...)
+		}
+	}
+
+	connections, err := GetMetroclusterConnectionData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, connection := range connections {
+		ch <- prometheus.MustNewConstMetric(metroclusterConnectionCompletedDesc, prometheus.GaugeValue, utils.BoolToFloat64(connection.ConfigState == "completed"), append(variables.BaseLabelValues, connection.Node, connection.HomePort, connection.RelationshipType, connection.Partner, connection.SourceAddress, connection.DestinationAddress)...)
+	}
+	return nil
+}
+
+func GetMetroclusterData(netappClient *netapp.Client) (*Metrocluster, error) {
+
+	api := struct {
+		XMLName xml.Name `xml:"metrocluster-get"`
+	}{}
+	var res metroclusterGetResponse
+	if err := zapiInvoke(netappClient, &api, &res); err != nil {
+		return nil, fmt.Errorf("metrocluster-get: %s", err)
+	}
+
+	n := res.Results.MetroclusterInfo
+	return &Metrocluster{
+		ConfigurationType:        n.ConfigurationType,
+		LocalClusterName:         n.LocalClusterName,
+		LocalConfigurationState:  n.LocalConfigurationState,
+		LocalMode:                n.LocalMode,
+		RemoteClusterName:        n.RemoteClusterName,
+		RemoteConfigurationState: n.RemoteConfigurationState,
+		RemoteMode:               n.RemoteMode,
+	}, nil
+}
+
+func GetMetroclusterNodeData(netappClient *netapp.Client) (r []*MetroclusterNode, err error) {
+
+	var l struct {
+		MetroclusterNodeInfo []metroclusterNodeInfo `xml:"metrocluster-node-info"`
+	}
+	if err = zapiGetIter(netappClient, "metrocluster-node-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.MetroclusterNodeInfo {
+		r = append(r, &MetroclusterNode{
+			Node:               n.NodeName,
+			ClusterName:        n.ClusterName,
+			DrGroupId:          n.DrGroupId,
+			DrPartner:          n.DrPartnerName,
+			DrAuxiliary:        n.DrAuxiliaryName,
+			HaPartner:          n.HaPartnerName,
+			ConfigurationState: n.ConfigurationState,
+			DrMirroringState:   n.DrMirroringState,
+		})
+	}
+	return
+}
+
+func GetMetroclusterCheckData(netappClient *netapp.Client) (r []*MetroclusterCheck, err error) {
+
+	var l struct {
+		MetroclusterCheckInfo []metroclusterCheckInfo `xml:"metrocluster-check-info"`
+	}
+	if err = zapiGetIter(netappClient, "metrocluster-check-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.MetroclusterCheckInfo {
+		r = append(r, &MetroclusterCheck{
+			Component: n.Component,
+			Result:    n.Result,
+			Timestamp: n.Timestamp,
+		})
+	}
+	return
+}
+
+func GetMetroclusterConnectionData(netappClient *netapp.Client) (r []*MetroclusterConnection, err error) {
+
+	var l struct {
+		MetroclusterCheckConnectionInfo []metroclusterCheckConnectionInfo `xml:"metrocluster-check-connection-info"`
+	}
+	if err = zapiGetIter(netappClient, "metrocluster-check-connection-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.MetroclusterCheckConnectionInfo {
+		r = append(r, &MetroclusterConnection{
+			Node:               n.NodeName,
+			HomePort:           n.HomePort,
+			RelationshipType:   n.RelationshipType,
+			Partner:            n.PartnerName,
+			SourceAddress:      n.SourceAddress,
+			DestinationAddress: n.DestinationAddress,
+			ConfigState:        n.ConfigState,
+		})
+	}
+	return
+}
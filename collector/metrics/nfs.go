@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	NfsSubsystem = "nfs"
+)
+
+// Metric descriptors.
+var (
+	nfsClientLabels         = append(variables.BaseLabelNames, "vserver", "node", "lif", "protocol")
+	nfsConnectedClientsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, NfsSubsystem, "connected_clients"),
+		"Number of distinct NFS clients connected to the lif.",
+		nfsClientLabels, nil)
+	nfsConnectedVolumesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, NfsSubsystem, "connected_volumes"),
+		"Number of distinct volumes accessed by NFS clients through the lif.",
+		nfsClientLabels, nil)
+)
+
+// ScrapeNfsClient collects NFS connected clients info
+type ScrapeNfsClient struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeNfsClient) Name() string {
+	return NfsSubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeNfsClient) Help() string {
+	return "Collect Netapp NFS connected clients info;"
+}
+
+// Version of ZAPI from which the Scraper is available, connected clients are
+// tracked from ONTAP 9.7.
+func (ScrapeNfsClient) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 170}
+}
+
+type NfsClient struct {
+	Vserver  string
+	Node     string
+	ServerIp string
+	ClientIp string
+	Protocol string
+	Volume   string
+}
+
+type nfsConnectedClientsInfo struct {
+	Vserver    string `xml:"vserver"`
+	NodeName   string `xml:"node-name"`
+	LifIp      string `xml:"lif-ip"`
+	ClientIp   string `xml:"client-ip"`
+	Protocol   string `xml:"protocol"`
+	VolumeName string `xml:"volume-name"`
+}
+
+// nfsClientKey is the set of labels the clients are counted by.
+type nfsClientKey struct {
+	Vserver  string
+	Node     string
+	ServerIp string
+	Protocol string
+}
+
+// Scrape collects data from  netapp NFS connected clients info
+func (ScrapeNfsClient) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	nfsClients, err := GetNfsClientData(netappClient)
+	if err != nil {
+		return err
+	}
+
+	clients := make(map[nfsClientKey]map[string]bool)
+	volumes := make(map[nfsClientKey]map[string]bool)
+	for _, client := range nfsClients {
+		key := nfsClientKey{client.Vserver, client.Node, client.ServerIp, client.Protocol}
+		if clients[key] == nil {
+			clients[key] = make(map[string]bool)
+			volumes[key] = make(map[string]bool)
+		}
+		clients[key][client.ClientIp] = true
+		volumes[key][client.Volume] = true
+	}
+
+	for key, clientIps := range clients {
+		nfsClientLabelValues := append(variables.BaseLabelValues, key.Vserver, key.Node, key.ServerIp, key.Protocol)
+		ch <- prometheus.MustNewConstMetric(nfsConnectedClientsDesc, prometheus.GaugeValue, float64(len(clientIps)), nfsClientLabelValues...)
+		ch <- prometheus.MustNewConstMetric(nfsConnectedVolumesDesc, prometheus.GaugeValue, float64(len(volumes[key])), nfsClientLabelValues...)
+	}
+	return nil
+}
+
+func GetNfsClientData(netappClient *netapp.Client) (r []*NfsClient, err error) {
+
+	var l struct {
+		NfsConnectedClientsInfo []nfsConnectedClientsInfo `xml:"nfs-connected-clients-info"`
+	}
+	if err = zapiGetIter(netappClient, "nfs-connected-clients-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.NfsConnectedClientsInfo {
+		r = append(r, &NfsClient{
+			Vserver:  n.Vserver,
+			Node:     n.NodeName,
+			ServerIp: n.LifIp,
+			ClientIp: n.ClientIp,
+			Protocol: n.Protocol,
+			Volume:   n.VolumeName,
+		})
+	}
+	return
+}
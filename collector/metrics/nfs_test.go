@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// nfsConnectedClientsReply is a reply of nfs-connected-clients-get-iter, a
+// client is listed once for each volume it accesses.
+const nfsConnectedClientsReply = `<?xml version='1.0' encoding='UTF-8' ?>
+<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'>
+<results status="passed">
+<attributes-list>
+<nfs-connected-clients-info><client-ip>10.0.0.11</client-ip><idle-time>5</idle-time><lif-ip>10.0.0.1</lif-ip><local-request-count>12</local-request-count><node-name>node-01</node-name><protocol>nfs3</protocol><remote-request-count>0</remote-request-count><volume-name>vol1</volume-name><vserver>svm1</vserver></nfs-connected-clients-info>
+<nfs-connected-clients-info><client-ip>10.0.0.11</client-ip><idle-time>5</idle-time><lif-ip>10.0.0.1</lif-ip><local-request-count>3</local-request-count><node-name>node-01</node-name><protocol>nfs3</protocol><remote-request-count>0</remote-request-count><volume-name>vol2</volume-name><vserver>svm1</vserver></nfs-connected-clients-info>
+<nfs-connected-clients-info><client-ip>10.0.0.12</client-ip><idle-time>9</idle-time><lif-ip>10.0.0.1</lif-ip><local-request-count>7</local-request-count><node-name>node-01</node-name><protocol>nfs3</protocol><remote-request-count>0</remote-request-count><volume-name>vol1</volume-name><vserver>svm1</vserver></nfs-connected-clients-info>
+<nfs-connected-clients-info><client-ip>10.0.0.13</client-ip><idle-time>1</idle-time><lif-ip>10.0.0.2</lif-ip><local-request-count>1</local-request-count><node-name>node-02</node-name><protocol>nfs4</protocol><remote-request-count>0</remote-request-count><volume-name>vol3</volume-name><vserver>svm1</vserver></nfs-connected-clients-info>
+</attributes-list>
+<num-records>4</num-records>
+</results>
+</netapp>`
+
+func TestGetNfsClientData(t *testing.T) {
+	netappClient, stop := newZapiTestClient(map[string]string{"nfs-connected-clients-get-iter": nfsConnectedClientsReply})
+	defer stop()
+
+	clients, err := GetNfsClientData(netappClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clients) != 4 {
+		t.Fatalf("got %d clients, want 4", len(clients))
+	}
+	want := NfsClient{Vserver: "svm1", Node: "node-01", ServerIp: "10.0.0.1", ClientIp: "10.0.0.11", Protocol: "nfs3", Volume: "vol1"}
+	if *clients[0] != want {
+		t.Errorf("got %+v, want %+v", *clients[0], want)
+	}
+}
+
+func TestScrapeNfsClient(t *testing.T) {
+	netappClient, stop := newZapiTestClient(map[string]string{"nfs-connected-clients-get-iter": nfsConnectedClientsReply})
+	defer stop()
+
+	expected := `
+# HELP netapp_nfs_connected_clients Number of distinct NFS clients connected to the lif.
+# TYPE netapp_nfs_connected_clients gauge
+netapp_nfs_connected_clients{cluster="",group="",lif="10.0.0.1",node="node-01",protocol="nfs3",vserver="svm1"} 2
+netapp_nfs_connected_clients{cluster="",group="",lif="10.0.0.2",node="node-02",protocol="nfs4",vserver="svm1"} 1
+# HELP netapp_nfs_connected_volumes Number of distinct volumes accessed by NFS clients through the lif.
+# TYPE netapp_nfs_connected_volumes gauge
+netapp_nfs_connected_volumes{cluster="",group="",lif="10.0.0.1",node="node-01",protocol="nfs3",vserver="svm1"} 2
+netapp_nfs_connected_volumes{cluster="",group="",lif="10.0.0.2",node="node-02",protocol="nfs4",vserver="svm1"} 1
+`
+	c := scraperCollector{t: t, scraper: ScrapeNfsClient{}, netappClient: netappClient}
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
@@ -38,6 +38,11 @@ func (sp *ScrapePerf) Help() string {
 	return "Collect Netapp Perf info;"
 }
 
+// Version of ZAPI from which the Scraper is available.
+func (sp *ScrapePerf) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 20}
+}
+
 // Scrape collects data from  netapp system and Perf info
 func (sp *ScrapePerf) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
 	for _, object := range sp.PerformanceObj {
@@ -114,6 +119,9 @@ func GetPerfObjectInstanceInfo(netappClient *netapp.Client, objectName string) (
 
 	var perfInstanceUuids []string
 	perfInstanceList := GetPerfObjectInstanceList(netappClient, objectName)
+	if len(perfInstanceList) == 0 { // the object has no instance or doesn't exist on this release, e.g. object_store_client_op before 9.2
+		return
+	}
 
 	for _, perfInstance := range perfInstanceList {
 		perfInstanceUuids = append(perfInstanceUuids, perfInstance.Uuid)
@@ -148,8 +156,9 @@ func GetPerfObjectInstanceList(netappClient *netapp.Client, objectName string) (
 		ObjectName:        objectName,
 	}
 	resp, _, err := netappClient.Perf.PerfObjectInstanceListInfoIter(opts)
-	if err !=nil  {
-		log.Fatalf("error when getting perf list, %s",err)
+	if err != nil {
+		log.Printf("error when getting perf list of %s, %s", objectName, err)
+		return
 	}
 	r = resp.Results.AttributesList.InstanceInfo // return a slice of instances
 	return
@@ -0,0 +1,309 @@
+package metrics
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	QosSubsystem = "qos"
+)
+
+// Metric descriptors.
+var (
+	qosPolicyGroupLabels           = append(variables.BaseLabelNames, "policy_group", "vserver", "class")
+	qosPolicyMaxThroughputIopsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, QosSubsystem, "policy_max_throughput_iops"),
+		"Max throughput of the QoS policy group in IOPS, not set if unlimited.",
+		qosPolicyGroupLabels, nil)
+	qosPolicyMaxThroughputMbpsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, QosSubsystem, "policy_max_throughput_mbps"),
+		"Max throughput of the QoS policy group in MB/s, not set if unlimited.",
+		qosPolicyGroupLabels, nil)
+	qosPolicyMinThroughputIopsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, QosSubsystem, "policy_min_throughput_iops"),
+		"Min throughput of the QoS policy group in IOPS.",
+		qosPolicyGroupLabels, nil)
+	qosPolicyMinThroughputMbpsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, QosSubsystem, "policy_min_throughput_mbps"),
+		"Min throughput of the QoS policy group in MB/s.",
+		qosPolicyGroupLabels, nil)
+	qosPolicyWorkloadsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, QosSubsystem, "policy_workloads"),
+		"Number of workloads in the QoS policy group.",
+		qosPolicyGroupLabels, nil)
+
+	qosAdaptivePolicyGroupLabels      = append(variables.BaseLabelNames, "policy_group", "vserver")
+	qosAdaptivePolicyExpectedIopsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, QosSubsystem, "adaptive_policy_expected_iops_per_tb"),
+		"Expected IOPS per TB of the adaptive QoS policy group.",
+		qosAdaptivePolicyGroupLabels, nil)
+	qosAdaptivePolicyPeakIopsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, QosSubsystem, "adaptive_policy_peak_iops_per_tb"),
+		"Peak IOPS per TB of the adaptive QoS policy group.",
+		qosAdaptivePolicyGroupLabels, nil)
+	qosAdaptivePolicyAbsoluteMinIopsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, QosSubsystem, "adaptive_policy_absolute_min_iops"),
+		"Absolute min IOPS of the adaptive QoS policy group.",
+		qosAdaptivePolicyGroupLabels, nil)
+	qosAdaptivePolicyWorkloadsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, QosSubsystem, "adaptive_policy_workloads"),
+		"Number of workloads in the adaptive QoS policy group.",
+		qosAdaptivePolicyGroupLabels, nil)
+
+	qosWorkloadInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, QosSubsystem, "workload_info"),
+		"Information of the QoS workload, value is always 1; join it on workload with the perf workload metrics to get the policy group.",
+		append(variables.BaseLabelNames, "workload", "policy_group", "vserver", "volume", "qtree", "lun", "class"), nil)
+)
+
+// ScrapeQos collects QoS policy group and workload info
+type ScrapeQos struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeQos) Name() string {
+	return QosSubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeQos) Help() string {
+	return "Collect Netapp QoS policy group and workload info;"
+}
+
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeQos) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 20}
+}
+
+// adaptive policy groups are available from ONTAP 9.3.
+var qosAdaptivePolicyGroupVersion = utils.ZapiVersion{Major: 1, Minor: 130}
+
+type QosPolicyGroup struct {
+	PolicyGroup      string
+	Vserver          string
+	PolicyGroupClass string
+	MaxThroughput    string
+	MinThroughput    string
+	NumWorkloads     int
+}
+
+type QosAdaptivePolicyGroup struct {
+	PolicyGroup     string
+	Vserver         string
+	ExpectedIops    string
+	PeakIops        string
+	AbsoluteMinIops string
+	NumWorkloads    int
+}
+
+type QosWorkload struct {
+	WorkloadName  string
+	PolicyGroup   string
+	Vserver       string
+	Volume        string
+	Qtree         string
+	Lun           string
+	WorkloadClass string
+}
+
+type qosPolicyGroupInfo struct {
+	PolicyGroup      string `xml:"policy-group"`
+	Vserver          string `xml:"vserver"`
+	PolicyGroupClass string `xml:"policy-group-class"`
+	MaxThroughput    string `xml:"max-throughput"`
+	MinThroughput    string `xml:"min-throughput"`
+	NumWorkloads     int    `xml:"num-workloads"`
+}
+
+type qosAdaptivePolicyGroupInfo struct {
+	PolicyGroup     string `xml:"policy-group"`
+	Vserver         string `xml:"vserver"`
+	ExpectedIops    string `xml:"expected-iops"`
+	PeakIops        string `xml:"peak-iops"`
+	AbsoluteMinIops string `xml:"absolute-min-iops"`
+	NumWorkloads    int    `xml:"num-workloads"`
+}
+
+type qosWorkloadInfo struct {
+	WorkloadName  string `xml:"workload-name"`
+	PolicyGroup   string `xml:"policy-group"`
+	Vserver       string `xml:"vserver"`
+	Volume        string `xml:"volume"`
+	Qtree         string `xml:"qtree"`
+	Lun           string `xml:"lun"`
+	WorkloadClass string `xml:"workload-class"`
+}
+
+// Scrape collects data from  netapp QoS policy group and workload info
+func (ScrapeQos) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	policyGroups, err := GetQosPolicyGroupData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, policyGroup := range policyGroups {
+		qosPolicyGroupLabelValues := append(variables.BaseLabelValues, policyGroup.PolicyGroup, policyGroup.Vserver, policyGroup.PolicyGroupClass)
+		maxIops, hasMaxIops, maxMbps, hasMaxMbps := parseQosThroughput(policyGroup.MaxThroughput)
+		if hasMaxIops {
+			ch <- prometheus.MustNewConstMetric(qosPolicyMaxThroughputIopsDesc, prometheus.GaugeValue, maxIops, qosPolicyGroupLabelValues...)
+		}
+		if hasMaxMbps {
+			ch <- prometheus.MustNewConstMetric(qosPolicyMaxThroughputMbpsDesc, prometheus.GaugeValue, maxMbps, qosPolicyGroupLabelValues...)
+		}
+		minIops, hasMinIops, minMbps, hasMinMbps := parseQosThroughput(policyGroup.MinThroughput)
+		if hasMinIops {
+			ch <- prometheus.MustNewConstMetric(qosPolicyMinThroughputIopsDesc, prometheus.GaugeValue, minIops, qosPolicyGroupLabelValues...)
+		}
+		if hasMinMbps {
+			ch <- prometheus.MustNewConstMetric(qosPolicyMinThroughputMbpsDesc, prometheus.GaugeValue, minMbps, qosPolicyGroupLabelValues...)
+		}
+		ch <- prometheus.MustNewConstMetric(qosPolicyWorkloadsDesc, prometheus.GaugeValue, float64(policyGroup.NumWorkloads), qosPolicyGroupLabelValues...)
+	}
+
+	if zapiSupports(netappClient, qosAdaptivePolicyGroupVersion) {
+		adaptivePolicyGroups, err := GetQosAdaptivePolicyGroupData(netappClient)
+		if err != nil {
+			return err
+		}
+		for _, policyGroup := range adaptivePolicyGroups {
+			qosAdaptivePolicyGroupLabelValues := append(variables.BaseLabelValues, policyGroup.PolicyGroup, policyGroup.Vserver)
+			if value, ok := parseQosIops(policyGroup.ExpectedIops); ok {
+				ch <- prometheus.MustNewConstMetric(qosAdaptivePolicyExpectedIopsDesc, prometheus.GaugeValue, value, qosAdaptivePolicyGroupLabelValues...)
+			}
+			if value, ok := parseQosIops(policyGroup.PeakIops); ok {
+				ch <- prometheus.MustNewConstMetric(qosAdaptivePolicyPeakIopsDesc, prometheus.GaugeValue, value, qosAdaptivePolicyGroupLabelValues...)
+			}
+			if value, ok := parseQosIops(policyGroup.AbsoluteMinIops); ok {
+				ch <- prometheus.MustNewConstMetric(qosAdaptivePolicyAbsoluteMinIopsDesc, prometheus.GaugeValue, value, qosAdaptivePolicyGroupLabelValues...)
+			}
+			ch <- prometheus.MustNewConstMetric(qosAdaptivePolicyWorkloadsDesc, prometheus.GaugeValue, float64(policyGroup.NumWorkloads), qosAdaptivePolicyGroupLabelValues...)
+		}
+	}
+
+	workloads, err := GetQosWorkloadData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, workload := range workloads {
+		ch <- prometheus.MustNewConstMetric(qosWorkloadInfoDesc, prometheus.GaugeValue, 1,
+			append(variables.BaseLabelValues, workload.WorkloadName, workload.PolicyGroup, workload.Vserver, workload.Volume, workload.Qtree, workload.Lun, workload.WorkloadClass)...)
+	}
+	return nil
+}
+
+func GetQosPolicyGroupData(netappClient *netapp.Client) (r []*QosPolicyGroup, err error) {
+
+	var l struct {
+		QosPolicyGroupInfo []qosPolicyGroupInfo `xml:"qos-policy-group-info"`
+	}
+	if err = zapiGetIter(netappClient, "qos-policy-group-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.QosPolicyGroupInfo {
+		r = append(r, &QosPolicyGroup{
+			PolicyGroup:      n.PolicyGroup,
+			Vserver:          n.Vserver,
+			PolicyGroupClass: n.PolicyGroupClass,
+			MaxThroughput:    n.MaxThroughput,
+			MinThroughput:    n.MinThroughput,
+			NumWorkloads:     n.NumWorkloads,
+		})
+	}
+	return
+}
+
+func GetQosAdaptivePolicyGroupData(netappClient *netapp.Client) (r []*QosAdaptivePolicyGroup, err error) {
+
+	var l struct {
+		QosAdaptivePolicyGroupInfo []qosAdaptivePolicyGroupInfo `xml:"qos-adaptive-policy-group-info"`
+	}
+	if err = zapiGetIter(netappClient, "qos-adaptive-policy-group-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.QosAdaptivePolicyGroupInfo {
+		r = append(r, &QosAdaptivePolicyGroup{
+			PolicyGroup:     n.PolicyGroup,
+			Vserver:         n.Vserver,
+			ExpectedIops:    n.ExpectedIops,
+			PeakIops:        n.PeakIops,
+			AbsoluteMinIops: n.AbsoluteMinIops,
+			NumWorkloads:    n.NumWorkloads,
+		})
+	}
+	return
+}
+
+func GetQosWorkloadData(netappClient *netapp.Client) (r []*QosWorkload, err error) {
+
+	var l struct {
+		QosWorkloadInfo []qosWorkloadInfo `xml:"qos-workload-info"`
+	}
+	if err = zapiGetIter(netappClient, "qos-workload-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.QosWorkloadInfo {
+		r = append(r, &QosWorkload{
+			WorkloadName:  n.WorkloadName,
+			PolicyGroup:   n.PolicyGroup,
+			Vserver:       n.Vserver,
+			Volume:        n.Volume,
+			Qtree:         n.Qtree,
+			Lun:           n.Lun,
+			WorkloadClass: n.WorkloadClass,
+		})
+	}
+	return
+}
+
+// qosThroughputUnits converts the byte units of a throughput to MB/s.
+var qosThroughputUnits = map[string]float64{
+	"B/S":  1.0 / 1024 / 1024,
+	"KB/S": 1.0 / 1024,
+	"MB/S": 1,
+	"GB/S": 1024,
+	"TB/S": 1024 * 1024,
+}
+
+// parseQosThroughput parses a throughput such as "1000IOPS", "100MB/S" or
+// "1000IOPS,100MB/S" into IOPS and MB/s, a part which is not set, or is
+// unlimited ("INF"), is reported as missing.
+func parseQosThroughput(data string) (iops float64, hasIops bool, mbps float64, hasMbps bool) {
+	for _, part := range strings.Split(strings.ToUpper(data), ",") {
+		part = strings.TrimSpace(part)
+		if value, ok := parseQosIops(part); ok {
+			iops, hasIops = value, true
+			continue
+		}
+		for unit, factor := range qosThroughputUnits {
+			if !strings.HasSuffix(part, unit) {
+				continue
+			}
+			// "B/S" is also the suffix of the other units, the number only
+			// parses with the full unit removed.
+			if value, err := strconv.ParseFloat(strings.TrimSuffix(part, unit), 64); err == nil {
+				mbps, hasMbps = value*factor, true
+				break
+			}
+		}
+	}
+	return
+}
+
+// parseQosIops parses an IOPS value such as "1000IOPS" or "128IOPS/TB".
+func parseQosIops(data string) (float64, bool) {
+	data = strings.TrimSuffix(strings.ToUpper(strings.TrimSpace(data)), "/TB")
+	if !strings.HasSuffix(data, "IOPS") {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(strings.TrimSuffix(data, "IOPS"), 64)
+	return value, err == nil
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import "testing"
+
+func TestParseQosThroughput(t *testing.T) {
+	tests := []struct {
+		data    string
+		iops    float64
+		hasIops bool
+		mbps    float64
+		hasMbps bool
+	}{
+		{"1000IOPS", 1000, true, 0, false},
+		{"100MB/S", 0, false, 100, true},
+		{"1000IOPS,100MB/S", 1000, true, 100, true},
+		{"1000IOPS, 100MB/S", 1000, true, 100, true},
+		{"1.5GB/S", 0, false, 1536, true},
+		{"512KB/S", 0, false, 0.5, true},
+		{"1048576B/S", 0, false, 1, true},
+		{"2TB/S", 0, false, 2 * 1024 * 1024, true},
+		{"100mb/s", 0, false, 100, true},
+		{"INF", 0, false, 0, false},
+		{"", 0, false, 0, false},
+		{"fastIOPS", 0, false, 0, false},
+		{"100XB/S", 0, false, 0, false},
+	}
+	for _, test := range tests {
+		iops, hasIops, mbps, hasMbps := parseQosThroughput(test.data)
+		if iops != test.iops || hasIops != test.hasIops || mbps != test.mbps || hasMbps != test.hasMbps {
+			t.Errorf("parseQosThroughput(%q) = %v, %v, %v, %v; want %v, %v, %v, %v", test.data, iops, hasIops, mbps, hasMbps, test.iops, test.hasIops, test.mbps, test.hasMbps)
+		}
+	}
+}
+
+func TestParseQosIops(t *testing.T) {
+	tests := []struct {
+		data  string
+		value float64
+		ok    bool
+	}{
+		{"1000IOPS", 1000, true},
+		{"128IOPS/TB", 128, true},
+		{" 6144iops/tb ", 6144, true},
+		{"100MB/S", 0, false},
+		{"IOPS", 0, false},
+		{"", 0, false},
+	}
+	for _, test := range tests {
+		value, ok := parseQosIops(test.data)
+		if value != test.value || ok != test.ok {
+			t.Errorf("parseQosIops(%q) = %v, %v; want %v, %v", test.data, value, ok, test.value, test.ok)
+		}
+	}
+}
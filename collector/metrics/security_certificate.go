@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"strconv"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	SecurityCertificateSubsystem = "security_certificate"
+)
+
+// Metric descriptors.
+var (
+	securityCertificateLabels     = append(variables.BaseLabelNames, "vserver", "common_name", "type", "serial_number", "self_signed")
+	securityCertificateExpiryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SecurityCertificateSubsystem, "expiry_timestamp_seconds"),
+		"Expiry time of the certificate, self_signed is empty if the certificate could not be parsed.",
+		securityCertificateLabels, nil)
+)
+
+// ScrapeSecurityCertificate collects security certificate info
+type ScrapeSecurityCertificate struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeSecurityCertificate) Name() string {
+	return SecurityCertificateSubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeSecurityCertificate) Help() string {
+	return "Collect Netapp security certificate info;"
+}
+
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeSecurityCertificate) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 20}
+}
+
+type SecurityCertificate struct {
+	Vserver        string
+	CommonName     string
+	Type           string
+	SerialNumber   string
+	ExpirationDate int64
+	// SelfSigned is "true" or "false", or empty if unknown.
+	SelfSigned string
+}
+
+type securityCertificateInfo struct {
+	Vserver           string `xml:"vserver"`
+	CommonName        string `xml:"common-name"`
+	Type              string `xml:"type"`
+	SerialNumber      string `xml:"serial-number"`
+	ExpirationDate    int64  `xml:"expiration-date"`
+	PublicCertificate string `xml:"public-certificate"`
+}
+
+// Scrape collects data from  netapp security certificate info
+func (ScrapeSecurityCertificate) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	certificates, err := GetSecurityCertificateData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, certificate := range certificates {
+		securityCertificateLabelValues := append(variables.BaseLabelValues, certificate.Vserver, certificate.CommonName, certificate.Type, certificate.SerialNumber, certificate.SelfSigned)
+		ch <- prometheus.MustNewConstMetric(securityCertificateExpiryDesc, prometheus.GaugeValue, float64(certificate.ExpirationDate), securityCertificateLabelValues...)
+	}
+	return nil
+}
+
+func GetSecurityCertificateData(netappClient *netapp.Client) (r []*SecurityCertificate, err error) {
+
+	var l struct {
+		CertificateInfo []securityCertificateInfo `xml:"certificate-info"`
+	}
+	if err = zapiGetIter(netappClient, "security-certificate-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.CertificateInfo {
+		r = append(r, &SecurityCertificate{
+			Vserver:        n.Vserver,
+			CommonName:     n.CommonName,
+			Type:           n.Type,
+			SerialNumber:   n.SerialNumber,
+			ExpirationDate: n.ExpirationDate,
+			SelfSigned:     isSelfSigned(n.PublicCertificate),
+		})
+	}
+	return
+}
+
+// isSelfSigned reports whether the PEM certificate is issued by its own subject,
+// it returns an empty string if the certificate can't be parsed.
+func isSelfSigned(publicCertificate string) string {
+	block, _ := pem.Decode([]byte(publicCertificate))
+	if block == nil {
+		return ""
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return ""
+	}
+	return strconv.FormatBool(bytes.Equal(cert.RawIssuer, cert.RawSubject))
+}
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// testCertificate returns a PEM certificate for subject issued by parent, or
+// self-signed if parent is nil.
+func testCertificate(t *testing.T, subject pkix.Name, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               subject,
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  parent == nil,
+		BasicConstraintsValid: true,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestIsSelfSigned(t *testing.T) {
+	ca, caKey, caPem := testCertificate(t, pkix.Name{CommonName: "cluster1", Organization: []string{"Example CA"}}, nil, nil)
+	// the common name of the server certificate is the one of its CA.
+	_, _, serverPem := testCertificate(t, pkix.Name{CommonName: "cluster1", Organization: []string{"Example"}}, ca, caKey)
+	_, _, selfSignedPem := testCertificate(t, pkix.Name{CommonName: "svm1", Organization: []string{"Example"}}, nil, nil)
+
+	tests := []struct {
+		name     string
+		cert     string
+		expected string
+	}{
+		{"ca", caPem, "true"},
+		{"ca signed with the common name of the ca", serverPem, "false"},
+		{"self-signed", selfSignedPem, "true"},
+		{"missing", "", ""},
+		{"invalid", "-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n", ""},
+	}
+	for _, test := range tests {
+		if selfSigned := isSelfSigned(test.cert); selfSigned != test.expected {
+			t.Errorf("%s: got %q, expected %q", test.name, selfSigned, test.expected)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package metrics
+
+import (
+	"encoding/xml"
+	"log"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	ServiceProcessorSubsystem = "service_processor"
+)
+
+// Metric descriptors.
+var (
+	serviceProcessorLabels     = append(variables.BaseLabelNames, "node", "type")
+	serviceProcessorStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ServiceProcessorSubsystem, "status"),
+		"Status of the service processor, 1(online), 0(offline), 2(degraded), 3(rebooting), or 4(updating).",
+		serviceProcessorLabels, nil)
+	serviceProcessorInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ServiceProcessorSubsystem, "info"),
+		"Information of the service processor, type is one of sp, bmc or rlm.",
+		append(serviceProcessorLabels, "firmware_version", "part_number", "serial_number"), nil)
+	serviceProcessorAutoupdateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ServiceProcessorSubsystem, "is_autoupdate_enabled"),
+		"whether the firmware of the service processor is updated automatically.",
+		serviceProcessorLabels, nil)
+	serviceProcessorUpdateInProgressDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ServiceProcessorSubsystem, "update_in_progress"),
+		"whether a firmware update of the service processor is in progress.",
+		serviceProcessorLabels, nil)
+	serviceProcessorLastUpdateStateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ServiceProcessorSubsystem, "last_update_state"),
+		"Outcome of the last firmware update of the service processor, 1(passed), or 0(failed).",
+		serviceProcessorLabels, nil)
+	serviceProcessorLastUpdateEndDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ServiceProcessorSubsystem, "last_update_end_timestamp_seconds"),
+		"Time the last firmware update of the service processor ended.",
+		serviceProcessorLabels, nil)
+
+	serviceProcessorNetworkLabels      = append(variables.BaseLabelNames, "node", "address_type")
+	serviceProcessorNetworkEnabledDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ServiceProcessorSubsystem, "network_is_enabled"),
+		"whether the network interface of the service processor is enabled for the address type.",
+		serviceProcessorNetworkLabels, nil)
+	serviceProcessorNetworkSetupStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ServiceProcessorSubsystem, "network_setup_status"),
+		"Status of the IP configuration of the service processor, 1(succeeded), 0(failed), 2(in_progress), or 3(not_setup).",
+		serviceProcessorNetworkLabels, nil)
+	serviceProcessorNetworkLinkUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, ServiceProcessorSubsystem, "network_link_is_up"),
+		"whether the link of the service processor is up.",
+		serviceProcessorNetworkLabels, nil)
+)
+
+var (
+	serviceProcessorStatus = map[string]float64{
+		"offline":   0,
+		"online":    1,
+		"degraded":  2,
+		"rebooting": 3,
+		"updating":  4,
+	}
+	serviceProcessorUpdateState = map[string]float64{
+		"failed": 0,
+		"passed": 1,
+	}
+	serviceProcessorSetupStatus = map[string]float64{
+		"failed":      0,
+		"succeeded":   1,
+		"in_progress": 2,
+		"not_setup":   3,
+	}
+)
+
+// ScrapeServiceProcessor collects service processor info
+type ScrapeServiceProcessor struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeServiceProcessor) Name() string {
+	return ServiceProcessorSubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeServiceProcessor) Help() string {
+	return "Collect Netapp service processor info;"
+}
+
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeServiceProcessor) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 21}
+}
+
+type ServiceProcessor struct {
+	Node                string
+	Type                string
+	Status              string
+	FirmwareVersion     string
+	PartNumber          string
+	SerialNumber        string
+	IsAutoupdateEnabled bool
+}
+
+type ServiceProcessorUpdateProgress struct {
+	Node            string
+	IsInProgress    bool
+	LastUpdateState string
+	EndTime         int64
+}
+
+type ServiceProcessorNetwork struct {
+	Node        string
+	AddressType string
+	IsEnabled   bool
+	SetupStatus string
+	LinkStatus  string
+}
+
+type serviceProcessorInfo struct {
+	Node                string `xml:"node"`
+	Type                string `xml:"type"`
+	Status              string `xml:"status"`
+	FirmwareVersion     string `xml:"firmware-version"`
+	PartNum             string `xml:"part-num"`
+	SerialNum           string `xml:"serial-num"`
+	IsAutoupdateEnabled bool   `xml:"is-autoupdate-enabled"`
+}
+
+type serviceProcessorImageUpdateProgressResponse struct {
+	XMLName xml.Name `xml:"netapp"`
+	Results struct {
+		ProgressInfo struct {
+			Node            string `xml:"node"`
+			IsInProgress    bool   `xml:"is-in-progress"`
+			LastUpdateState string `xml:"last-update-state"`
+			EndTime         int64  `xml:"end-time"`
+		} `xml:"attributes>service-processor-image-update-progress-info"`
+	} `xml:"results"`
+}
+
+type serviceProcessorNetworkInfo struct {
+	Node        string `xml:"node"`
+	AddressType string `xml:"address-type"`
+	IsEnabled   bool   `xml:"is-enabled"`
+	SetupStatus string `xml:"setup-status"`
+	LinkStatus  string `xml:"link-status"`
+}
+
+// Scrape collects data from  netapp service processor info
+func (ScrapeServiceProcessor) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	serviceProcessors, err := GetServiceProcessorData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, serviceProcessor := range serviceProcessors {
+		serviceProcessorLabelValues := append(variables.BaseLabelValues, serviceProcessor.Node, serviceProcessor.Type)
+		if value, ok := serviceProcessorStatus[serviceProcessor.Status]; ok {
+			ch <- prometheus.MustNewConstMetric(serviceProcessorStatusDesc, prometheus.GaugeValue, value, serviceProcessorLabelValues...)
+		}
+		ch <- prometheus.MustNewConstMetric(serviceProcessorInfoDesc, prometheus.GaugeValue, 1, append(serviceProcessorLabelValues, serviceProcessor.FirmwareVersion, serviceProcessor.PartNumber, serviceProcessor.SerialNumber)...)
+		ch <- prometheus.MustNewConstMetric(serviceProcessorAutoupdateDesc, prometheus.GaugeValue, utils.BoolToFloat64(serviceProcessor.IsAutoupdateEnabled), serviceProcessorLabelValues...)
+
+		// a node without update progress, e.g. its service processor is
+		// offline, keeps the other metrics of the service processors.
+		progress, err := GetServiceProcessorUpdateProgressData(netappClient, serviceProcessor.Node)
+		if err != nil {
+			log.Printf("service-processor-image-update-progress-get of %s: %s", serviceProcessor.Node, err)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(serviceProcessorUpdateInProgressDesc, prometheus.GaugeValue, utils.BoolToFloat64(progress.IsInProgress), serviceProcessorLabelValues...)
+		if value, ok := serviceProcessorUpdateState[progress.LastUpdateState]; ok {
+			ch <- prometheus.MustNewConstMetric(serviceProcessorLastUpdateStateDesc, prometheus.GaugeValue, value, serviceProcessorLabelValues...)
+		}
+		if progress.EndTime > 0 {
+			ch <- prometheus.MustNewConstMetric(serviceProcessorLastUpdateEndDesc, prometheus.GaugeValue, float64(progress.EndTime), serviceProcessorLabelValues...)
+		}
+	}
+
+	networks, err := GetServiceProcessorNetworkData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, network := range networks {
+		networkLabelValues := append(variables.BaseLabelValues, network.Node, network.AddressType)
+		ch <- prometheus.MustNewConstMetric(serviceProcessorNetworkEnabledDesc, prometheus.GaugeValue, utils.BoolToFloat64(network.IsEnabled), networkLabelValues...)
+		if value, ok := serviceProcessorSetupStatus[network.SetupStatus]; ok {
+			ch <- prometheus.MustNewConstMetric(serviceProcessorNetworkSetupStatusDesc, prometheus.GaugeValue, value, networkLabelValues...)
+		}
+		if network.IsEnabled {
+			ch <- prometheus.MustNewConstMetric(serviceProcessorNetworkLinkUpDesc, prometheus.GaugeValue, utils.BoolToFloat64(network.LinkStatus == "up"), networkLabelValues...)
+		}
+	}
+	return nil
+}
+
+func GetServiceProcessorData(netappClient *netapp.Client) (r []*ServiceProcessor, err error) {
+
+	var l struct {
+		ServiceProcessorInfo []serviceProcessorInfo `xml:"service-processor-info"`
+	}
+	if err = zapiGetIter(netappClient, "service-processor-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.ServiceProcessorInfo {
+		r = append(r, &ServiceProcessor{
+			Node:                n.Node,
+			Type:                n.Type,
+			Status:              n.Status,
+			FirmwareVersion:     n.FirmwareVersion,
+			PartNumber:          n.PartNum,
+			SerialNumber:        n.SerialNum,
+			IsAutoupdateEnabled: n.IsAutoupdateEnabled,
+		})
+	}
+	return
+}
+
+// GetServiceProcessorUpdateProgressData returns the progress of the current or
+// last firmware update of the service processor of node.
+func GetServiceProcessorUpdateProgressData(netappClient *netapp.Client, node string) (*ServiceProcessorUpdateProgress, error) {
+	api := struct {
+		XMLName xml.Name `xml:"service-processor-image-update-progress-get"`
+		Node    string   `xml:"node"`
+	}{Node: node}
+	var res serviceProcessorImageUpdateProgressResponse
+	if err := zapiInvoke(netappClient, &api, &res); err != nil {
+		return nil, err
+	}
+	n := res.Results.ProgressInfo
+	return &ServiceProcessorUpdateProgress{
+		Node:            node,
+		IsInProgress:    n.IsInProgress,
+		LastUpdateState: n.LastUpdateState,
+		EndTime:         n.EndTime,
+	}, nil
+}
+
+func GetServiceProcessorNetworkData(netappClient *netapp.Client) (r []*ServiceProcessorNetwork, err error) {
+
+	var l struct {
+		ServiceProcessorNetworkInfo []serviceProcessorNetworkInfo `xml:"service-processor-network-info"`
+	}
+	if err = zapiGetIter(netappClient, "service-processor-network-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.ServiceProcessorNetworkInfo {
+		r = append(r, &ServiceProcessorNetwork{
+			Node:        n.Node,
+			AddressType: n.AddressType,
+			IsEnabled:   n.IsEnabled,
+			SetupStatus: n.SetupStatus,
+			LinkStatus:  n.LinkStatus,
+		})
+	}
+	return
+}
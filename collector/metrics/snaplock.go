@@ -0,0 +1,296 @@
+package metrics
+
+import (
+	"encoding/xml"
+	"log"
+	"math"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	SnaplockSubsystem = "snaplock"
+
+	// snaplockAttrsTTL is how long the SnapLock attributes of a volume are
+	// cached, they are read per volume and rarely change.
+	snaplockAttrsTTL = time.Hour
+	// snaplockAttrsWorkers is the number of volumes whose SnapLock attributes
+	// are read at the same time.
+	snaplockAttrsWorkers = 4
+)
+
+// Metric descriptors.
+var (
+	snaplockLabels   = append(variables.BaseLabelNames, "volume", "vserver")
+	snaplockInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SnaplockSubsystem, "volume_info"),
+		"Information of the SnapLock volume, type is compliance or enterprise.",
+		append(snaplockLabels, "type"), nil)
+	snaplockRetentionPeriodDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SnaplockSubsystem, "retention_period_seconds"),
+		"Retention period of the SnapLock volume, setting is one of default, minimum or maximum; a month is 30 days, a year 365 days and infinite is +Inf.",
+		append(snaplockLabels, "setting"), nil)
+	snaplockAutocommitPeriodDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SnaplockSubsystem, "autocommit_period_seconds"),
+		"Autocommit period of the SnapLock volume, a month is 30 days and a year 365 days; not exported when autocommit is disabled.",
+		snaplockLabels, nil)
+	snaplockPrivilegedDeleteDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SnaplockSubsystem, "privileged_delete"),
+		"Privileged delete state of the SnapLock volume, 0(disabled), 1(enabled), or 2(permanently_disabled).",
+		snaplockLabels, nil)
+	snaplockExpiryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SnaplockSubsystem, "volume_expiry_timestamp_seconds"),
+		"Expiry time of the SnapLock volume, the time the last file of it is retained until, read at most hourly.",
+		snaplockLabels, nil)
+	snaplockAttrsReadErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SnaplockSubsystem, "attrs_read_errors"),
+		"Number of SnapLock volumes whose SnapLock attributes could not be read, only their volume_info is exported.",
+		variables.BaseLabelNames, nil)
+)
+
+var snaplockPrivilegedDelete = map[string]float64{
+	"disabled":             0,
+	"enabled":              1,
+	"permanently_disabled": 2,
+}
+
+// snaplockPeriodRegexp matches a SnapLock period such as "30years" or "4hours".
+var snaplockPeriodRegexp = regexp.MustCompile(`^([0-9]+)\s*(seconds|minutes|hours|days|months|years)$`)
+
+var snaplockPeriodUnits = map[string]float64{
+	"seconds": 1,
+	"minutes": 60,
+	"hours":   3600,
+	"days":    86400,
+	"months":  30 * 86400,
+	"years":   365 * 86400,
+}
+
+// ScrapeSnaplock collects SnapLock volume info
+type ScrapeSnaplock struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeSnaplock) Name() string {
+	return SnaplockSubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeSnaplock) Help() string {
+	return "Collect Netapp SnapLock volume info;"
+}
+
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeSnaplock) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 100}
+}
+
+type SnaplockVolume struct {
+	Volume                 string
+	Vserver                string
+	Type                   string
+	DefaultRetentionPeriod string
+	MinimumRetentionPeriod string
+	MaximumRetentionPeriod string
+	AutocommitPeriod       string
+	PrivilegedDelete       string
+	VolumeExpiryTime       string
+}
+
+// volumeSnaplockInfo is both the desired attributes and the record of
+// volume-get-iter for the SnapLock type of the volumes.
+type volumeSnaplockInfo struct {
+	XMLName            xml.Name `xml:"volume-attributes"`
+	VolumeIDAttributes struct {
+		Name              string `xml:"name"`
+		OwningVserverName string `xml:"owning-vserver-name"`
+	} `xml:"volume-id-attributes"`
+	VolumeSnaplockAttributes struct {
+		SnaplockType string `xml:"snaplock-type"`
+	} `xml:"volume-snaplock-attributes"`
+}
+
+// snaplockAttrs are the SnapLock attributes of a volume.
+type snaplockAttrs struct {
+	DefaultRetentionPeriod string `xml:"default-retention-period"`
+	MinimumRetentionPeriod string `xml:"minimum-retention-period"`
+	MaximumRetentionPeriod string `xml:"maximum-retention-period"`
+	AutocommitPeriod       string `xml:"autocommit-period"`
+	PrivilegedDelete       string `xml:"privileged-delete"`
+	VolumeExpiryTime       string `xml:"volume-expiry-time"`
+	fetched                time.Time
+}
+
+type volumeGetSnaplockAttrsResponse struct {
+	XMLName xml.Name `xml:"netapp"`
+	Results struct {
+		SnaplockAttrsInfo snaplockAttrs `xml:"snaplock-attrs>snaplock-attrs-info"`
+	} `xml:"results"`
+}
+
+var (
+	snaplockAttrsMu    sync.Mutex
+	snaplockAttrsCache = make(map[string]*snaplockAttrs)
+)
+
+// Scrape collects data from  netapp SnapLock info
+func (ScrapeSnaplock) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	volumes, failed, err := GetSnaplockVolumeData(netappClient)
+	if err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(snaplockAttrsReadErrorsDesc, prometheus.GaugeValue, float64(len(failed)), variables.BaseLabelValues...)
+	for _, volume := range volumes {
+		snaplockLabelValues := append(variables.BaseLabelValues, volume.Volume, volume.Vserver)
+		ch <- prometheus.MustNewConstMetric(snaplockInfoDesc, prometheus.GaugeValue, 1, append(snaplockLabelValues, volume.Type)...)
+		periods := []struct{ setting, period string }{
+			{"minimum", volume.MinimumRetentionPeriod},
+			{"maximum", volume.MaximumRetentionPeriod},
+			{"default", volume.DefaultRetentionPeriod},
+		}
+		retention := make(map[string]float64)
+		for _, p := range periods {
+			value, ok := parseSnaplockPeriod(p.period)
+			// the default retention period may be set to the minimum or the maximum.
+			if !ok && p.setting == "default" {
+				switch p.period {
+				case "min":
+					value, ok = retention["minimum"]
+				case "max":
+					value, ok = retention["maximum"]
+				}
+			}
+			if ok {
+				retention[p.setting] = value
+				ch <- prometheus.MustNewConstMetric(snaplockRetentionPeriodDesc, prometheus.GaugeValue, value, append(snaplockLabelValues, p.setting)...)
+			}
+		}
+		if value, ok := parseSnaplockPeriod(volume.AutocommitPeriod); ok {
+			ch <- prometheus.MustNewConstMetric(snaplockAutocommitPeriodDesc, prometheus.GaugeValue, value, snaplockLabelValues...)
+		}
+		if value, ok := snaplockPrivilegedDelete[volume.PrivilegedDelete]; ok {
+			ch <- prometheus.MustNewConstMetric(snaplockPrivilegedDeleteDesc, prometheus.GaugeValue, value, snaplockLabelValues...)
+		}
+		if value, err := strconv.ParseFloat(volume.VolumeExpiryTime, 64); err == nil && value > 0 {
+			ch <- prometheus.MustNewConstMetric(snaplockExpiryDesc, prometheus.GaugeValue, value, snaplockLabelValues...)
+		}
+	}
+	return nil
+}
+
+// parseSnaplockPeriod returns a SnapLock period in seconds, infinite is +Inf.
+func parseSnaplockPeriod(period string) (float64, bool) {
+	if period == "infinite" {
+		return math.Inf(1), true
+	}
+	match := snaplockPeriodRegexp.FindStringSubmatch(period)
+	if match == nil {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	return value * snaplockPeriodUnits[match[2]], true
+}
+
+// GetSnaplockVolumeData returns the SnapLock volumes with their SnapLock
+// attributes, which are read per volume with volume-get-snaplock-attrs and
+// cached for snaplockAttrsTTL. The volumes whose attributes could not be read
+// are returned in failed as vserver:volume, they are still in r.
+func GetSnaplockVolumeData(netappClient *netapp.Client) (r []*SnaplockVolume, failed []string, err error) {
+
+	var l struct {
+		VolumeAttributes []volumeSnaplockInfo `xml:"volume-attributes"`
+	}
+	if err = zapiGetIterAttributes(netappClient, "volume-get-iter", nil, &volumeSnaplockInfo{}, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.VolumeAttributes {
+		snaplockType := n.VolumeSnaplockAttributes.SnaplockType
+		if snaplockType == "" || snaplockType == "non_snaplock" {
+			continue
+		}
+		r = append(r, &SnaplockVolume{
+			Volume:  n.VolumeIDAttributes.Name,
+			Vserver: n.VolumeIDAttributes.OwningVserverName,
+			Type:    snaplockType,
+		})
+	}
+
+	volumes := make(chan *SnaplockVolume)
+	failedMu := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+	for i := 0; i < snaplockAttrsWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for volume := range volumes {
+				attrs, err := getSnaplockAttrs(netappClient, volume.Vserver, volume.Volume)
+				if err != nil {
+					log.Printf("volume-get-snaplock-attrs of %s:%s: %s", volume.Vserver, volume.Volume, err)
+					failedMu.Lock()
+					failed = append(failed, volume.Vserver+":"+volume.Volume)
+					failedMu.Unlock()
+					continue
+				}
+				volume.DefaultRetentionPeriod = attrs.DefaultRetentionPeriod
+				volume.MinimumRetentionPeriod = attrs.MinimumRetentionPeriod
+				volume.MaximumRetentionPeriod = attrs.MaximumRetentionPeriod
+				volume.AutocommitPeriod = attrs.AutocommitPeriod
+				volume.PrivilegedDelete = attrs.PrivilegedDelete
+				volume.VolumeExpiryTime = attrs.VolumeExpiryTime
+			}
+		}()
+	}
+	for _, volume := range r {
+		volumes <- volume
+	}
+	close(volumes)
+	wg.Wait()
+	return
+}
+
+// getSnaplockAttrs returns the SnapLock attributes of the volume of vserver,
+// from the cache if they were read within snaplockAttrsTTL.
+func getSnaplockAttrs(netappClient *netapp.Client, vserver, volume string) (*snaplockAttrs, error) {
+	key := netappClient.BaseURL.Host + "/" + vserver + "/" + volume
+
+	snaplockAttrsMu.Lock()
+	attrs, ok := snaplockAttrsCache[key]
+	snaplockAttrsMu.Unlock()
+	if ok && time.Since(attrs.fetched) < snaplockAttrsTTL {
+		return attrs, nil
+	}
+
+	api := struct {
+		XMLName xml.Name `xml:"volume-get-snaplock-attrs"`
+		Volume  string   `xml:"volume"`
+	}{Volume: volume}
+	var res volumeGetSnaplockAttrsResponse
+	if err := zapiInvokeVserver(netappClient, vserver, &api, &res); err != nil {
+		return nil, err
+	}
+	attrs = &res.Results.SnaplockAttrsInfo
+	attrs.fetched = time.Now()
+
+	snaplockAttrsMu.Lock()
+	defer snaplockAttrsMu.Unlock()
+	// the attributes of deleted volumes are dropped once expired.
+	for k, cached := range snaplockAttrsCache {
+		if time.Since(cached.fetched) >= snaplockAttrsTTL {
+			delete(snaplockAttrsCache, k)
+		}
+	}
+	snaplockAttrsCache[key] = attrs
+	return attrs, nil
+}
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestParseSnaplockPeriod(t *testing.T) {
+	tests := []struct {
+		period   string
+		expected float64
+		ok       bool
+	}{
+		{"30seconds", 30, true},
+		{"5minutes", 300, true},
+		{"4hours", 4 * 3600, true},
+		{"1days", 86400, true},
+		{"6months", 6 * 30 * 86400, true},
+		{"30years", 30 * 365 * 86400, true},
+		{"10 years", 10 * 365 * 86400, true},
+		{"infinite", math.Inf(1), true},
+		{"none", 0, false},
+		{"min", 0, false},
+		{"max", 0, false},
+		{"", 0, false},
+		{"years", 0, false},
+		{"3weeks", 0, false},
+		{"-1days", 0, false},
+	}
+	for _, test := range tests {
+		value, ok := parseSnaplockPeriod(test.period)
+		if ok != test.ok || value != test.expected {
+			t.Errorf("parseSnaplockPeriod(%q) = %v, %v, expected %v, %v", test.period, value, ok, test.expected, test.ok)
+		}
+	}
+}
+
+const (
+	snaplockVolumeGetIterReply = `<?xml version='1.0' encoding='UTF-8' ?>
+<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'>
+<results status="passed">
+<attributes-list>
+<volume-attributes><volume-id-attributes><name>worm1</name><owning-vserver-name>svm1</owning-vserver-name></volume-id-attributes><volume-snaplock-attributes><snaplock-type>compliance</snaplock-type></volume-snaplock-attributes></volume-attributes>
+<volume-attributes><volume-id-attributes><name>vol1</name><owning-vserver-name>svm1</owning-vserver-name></volume-id-attributes><volume-snaplock-attributes><snaplock-type>non_snaplock</snaplock-type></volume-snaplock-attributes></volume-attributes>
+</attributes-list>
+<num-records>2</num-records>
+</results>
+</netapp>`
+	snaplockAttrsReply = `<?xml version='1.0' encoding='UTF-8' ?>
+<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'>
+<results status="passed">
+<snaplock-attrs><snaplock-attrs-info><autocommit-period>none</autocommit-period><default-retention-period>max</default-retention-period><maximum-retention-period>30years</maximum-retention-period><minimum-retention-period>6months</minimum-retention-period><privileged-delete>disabled</privileged-delete><volume-expiry-time>1893456000</volume-expiry-time></snaplock-attrs-info></snaplock-attrs>
+</results>
+</netapp>`
+)
+
+// TestScrapeSnaplock checks the default retention period set to max is the
+// maximum one, and that the attributes are cached once the filer is gone.
+func TestScrapeSnaplock(t *testing.T) {
+	netappClient, stop := newZapiTestClient(map[string]string{
+		"volume-get-iter":           snaplockVolumeGetIterReply,
+		"volume-get-snaplock-attrs": snaplockAttrsReply,
+	})
+	defer stop()
+
+	expected := `
+# HELP netapp_snaplock_privileged_delete Privileged delete state of the SnapLock volume, 0(disabled), 1(enabled), or 2(permanently_disabled).
+# TYPE netapp_snaplock_privileged_delete gauge
+netapp_snaplock_privileged_delete{cluster="",group="",volume="worm1",vserver="svm1"} 0
+# HELP netapp_snaplock_retention_period_seconds Retention period of the SnapLock volume, setting is one of default, minimum or maximum; a month is 30 days, a year 365 days and infinite is +Inf.
+# TYPE netapp_snaplock_retention_period_seconds gauge
+netapp_snaplock_retention_period_seconds{cluster="",group="",setting="default",volume="worm1",vserver="svm1"} 9.4608e+08
+netapp_snaplock_retention_period_seconds{cluster="",group="",setting="maximum",volume="worm1",vserver="svm1"} 9.4608e+08
+netapp_snaplock_retention_period_seconds{cluster="",group="",setting="minimum",volume="worm1",vserver="svm1"} 1.5552e+07
+`
+	c := scraperCollector{t: t, scraper: ScrapeSnaplock{}, netappClient: netappClient}
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected), "netapp_snaplock_retention_period_seconds", "netapp_snaplock_privileged_delete", "netapp_snaplock_autocommit_period_seconds"); err != nil {
+		t.Error(err)
+	}
+
+	stop()
+	attrs, err := getSnaplockAttrs(netappClient, "svm1", "worm1")
+	if err != nil {
+		t.Fatalf("expected the cached attributes, got %s", err)
+	}
+	if attrs.MaximumRetentionPeriod != "30years" {
+		t.Errorf("got maximum retention period %q, expected 30years", attrs.MaximumRetentionPeriod)
+	}
+}
+
+// TestScrapeSnaplockAttrsFailure checks a volume whose SnapLock attributes
+// can't be read is counted and still exported with its type.
+func TestScrapeSnaplockAttrsFailure(t *testing.T) {
+	netappClient, stop := newZapiTestClient(map[string]string{
+		"volume-get-iter": strings.Replace(snaplockVolumeGetIterReply, "worm1", "worm2", -1),
+	})
+	defer stop()
+
+	expected := `
+# HELP netapp_snaplock_attrs_read_errors Number of SnapLock volumes whose SnapLock attributes could not be read, only their volume_info is exported.
+# TYPE netapp_snaplock_attrs_read_errors gauge
+netapp_snaplock_attrs_read_errors{cluster="",group=""} 1
+# HELP netapp_snaplock_volume_info Information of the SnapLock volume, type is compliance or enterprise.
+# TYPE netapp_snaplock_volume_info gauge
+netapp_snaplock_volume_info{cluster="",group="",type="compliance",volume="worm2",vserver="svm1"} 1
+`
+	c := scraperCollector{t: t, scraper: ScrapeSnaplock{}, netappClient: netappClient}
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected), "netapp_snaplock_attrs_read_errors", "netapp_snaplock_volume_info", "netapp_snaplock_retention_period_seconds"); err != nil {
+		t.Error(err)
+	}
+}
@@ -45,6 +45,11 @@ func (ScrapeSnapshot) Help() string {
 	return "Collect Netapp Snapshot info;"
 }
 
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeSnapshot) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 20}
+}
+
 type Snapshot struct {
 	Name    string
 	Busy    bool
@@ -0,0 +1,240 @@
+package metrics
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	SnapshotPolicySubsystem = "snapshot_policy"
+)
+
+// Metric descriptors.
+var (
+	snapshotPolicyLabels        = append(variables.BaseLabelNames, "vserver", "policy")
+	snapshotPolicyIsEnabledDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SnapshotPolicySubsystem, "is_enabled"),
+		"whether the snapshot policy is enabled.",
+		snapshotPolicyLabels, nil)
+	snapshotPolicyRetentionDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SnapshotPolicySubsystem, "schedule_retention"),
+		"Number of snapshots of the schedule the snapshot policy retains.",
+		append(snapshotPolicyLabels, "schedule", "prefix", "snapmirror_label"), nil)
+
+	snapshotPolicyVolumeLabels        = append(variables.BaseLabelNames, "volume", "vserver", "policy", "schedule", "prefix")
+	snapshotPolicyVolumeSnapshotsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SnapshotPolicySubsystem, "volume_snapshots"),
+		"Number of snapshots of the volume named with the prefix of the schedule.",
+		snapshotPolicyVolumeLabels, nil)
+	snapshotPolicyVolumeCompliantDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SnapshotPolicySubsystem, "volume_is_compliant"),
+		"whether the volume has at least as many snapshots of the schedule as the snapshot policy retains, which a new volume or schedule is not until the retention is filled.",
+		snapshotPolicyVolumeLabels, nil)
+)
+
+// ScrapeSnapshotPolicy collects snapshot policy info and the compliance of the volumes to it
+type ScrapeSnapshotPolicy struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeSnapshotPolicy) Name() string {
+	return SnapshotPolicySubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeSnapshotPolicy) Help() string {
+	return "Collect Netapp snapshot policy info and volume compliance;"
+}
+
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeSnapshotPolicy) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 20}
+}
+
+type SnapshotPolicy struct {
+	Vserver   string
+	Policy    string
+	Enabled   bool
+	Schedules []*SnapshotPolicySchedule
+}
+
+type SnapshotPolicySchedule struct {
+	Schedule        string
+	Count           int
+	Prefix          string
+	SnapmirrorLabel string
+}
+
+type snapshotPolicyInfo struct {
+	VserverName string `xml:"vserver-name"`
+	Policy      string `xml:"policy"`
+	Enabled     bool   `xml:"enabled"`
+	Schedules   []struct {
+		Schedule        string `xml:"schedule"`
+		Count           int    `xml:"count"`
+		Prefix          string `xml:"prefix"`
+		SnapmirrorLabel string `xml:"snapmirror-label"`
+	} `xml:"snapshot-policy-schedules>snapshot-schedule-info"`
+}
+
+// volumeSnapshotPolicyInfo is both the desired attributes and the record of
+// volume-get-iter for the snapshot policy of the volumes.
+type volumeSnapshotPolicyInfo struct {
+	XMLName            xml.Name `xml:"volume-attributes"`
+	VolumeIDAttributes struct {
+		Name              string `xml:"name"`
+		OwningVserverName string `xml:"owning-vserver-name"`
+	} `xml:"volume-id-attributes"`
+	VolumeSnapshotAttributes struct {
+		SnapshotPolicy string `xml:"snapshot-policy"`
+	} `xml:"volume-snapshot-attributes"`
+}
+
+// volumeSnapshotInfo is both the desired attributes and the record of
+// snapshot-get-iter for the names of the snapshots of the volumes.
+type volumeSnapshotInfo struct {
+	XMLName xml.Name `xml:"snapshot-info"`
+	Name    string   `xml:"name"`
+	Volume  string   `xml:"volume"`
+	Vserver string   `xml:"vserver"`
+}
+
+// Scrape collects data from  netapp snapshot policy info
+func (ScrapeSnapshotPolicy) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	policies, err := GetSnapshotPolicyData(netappClient)
+	if err != nil {
+		return err
+	}
+	// policies of the cluster are used by the volumes of every vserver.
+	policyByVserver := make(map[[2]string]*SnapshotPolicy)
+	policyByName := make(map[string]*SnapshotPolicy)
+	for _, policy := range policies {
+		snapshotPolicyLabelValues := append(variables.BaseLabelValues, policy.Vserver, policy.Policy)
+		ch <- prometheus.MustNewConstMetric(snapshotPolicyIsEnabledDesc, prometheus.GaugeValue, utils.BoolToFloat64(policy.Enabled), snapshotPolicyLabelValues...)
+		for _, schedule := range policy.Schedules {
+			ch <- prometheus.MustNewConstMetric(snapshotPolicyRetentionDesc, prometheus.GaugeValue, float64(schedule.Count), append(snapshotPolicyLabelValues, schedule.Schedule, schedule.Prefix, schedule.SnapmirrorLabel)...)
+		}
+		policyByVserver[[2]string{policy.Vserver, policy.Policy}] = policy
+		if _, ok := policyByName[policy.Policy]; !ok {
+			policyByName[policy.Policy] = policy
+		}
+	}
+
+	volumePolicies, err := GetVolumeSnapshotPolicyData(netappClient)
+	if err != nil {
+		return err
+	}
+	// a volume missing from the snapshots would be reported as not compliant,
+	// so the scrape fails rather than going on without them.
+	snapshotNames, err := GetVolumeSnapshotNameData(netappClient)
+	if err != nil {
+		return err
+	}
+
+	for key, policyName := range volumePolicies {
+		policy, ok := policyByVserver[[2]string{key[0], policyName}]
+		if !ok {
+			policy, ok = policyByName[policyName]
+		}
+		if !ok || !policy.Enabled {
+			continue
+		}
+		for _, schedule := range policy.Schedules {
+			if schedule.Count == 0 {
+				continue
+			}
+			snapshots := countSnapshotsWithPrefix(snapshotNames[key], schedule.Prefix)
+			volumeLabelValues := append(variables.BaseLabelValues, key[1], key[0], policy.Policy, schedule.Schedule, schedule.Prefix)
+			ch <- prometheus.MustNewConstMetric(snapshotPolicyVolumeSnapshotsDesc, prometheus.GaugeValue, float64(snapshots), volumeLabelValues...)
+			ch <- prometheus.MustNewConstMetric(snapshotPolicyVolumeCompliantDesc, prometheus.GaugeValue, utils.BoolToFloat64(snapshots >= schedule.Count), volumeLabelValues...)
+		}
+	}
+	return nil
+}
+
+// countSnapshotsWithPrefix counts the snapshots created by a schedule, which are
+// named <prefix>.<timestamp>.
+func countSnapshotsWithPrefix(names []string, prefix string) (count int) {
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix+".") {
+			count++
+		}
+	}
+	return
+}
+
+func GetSnapshotPolicyData(netappClient *netapp.Client) (r []*SnapshotPolicy, err error) {
+
+	var l struct {
+		SnapshotPolicyInfo []snapshotPolicyInfo `xml:"snapshot-policy-info"`
+	}
+	if err = zapiGetIter(netappClient, "snapshot-policy-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.SnapshotPolicyInfo {
+		policy := &SnapshotPolicy{
+			Vserver: n.VserverName,
+			Policy:  n.Policy,
+			Enabled: n.Enabled,
+		}
+		for _, s := range n.Schedules {
+			// the prefix defaults to the name of the schedule.
+			prefix := s.Prefix
+			if prefix == "" {
+				prefix = s.Schedule
+			}
+			policy.Schedules = append(policy.Schedules, &SnapshotPolicySchedule{
+				Schedule:        s.Schedule,
+				Count:           s.Count,
+				Prefix:          prefix,
+				SnapmirrorLabel: s.SnapmirrorLabel,
+			})
+		}
+		r = append(r, policy)
+	}
+	return
+}
+
+// GetVolumeSnapshotPolicyData returns the snapshot policy of each vserver and volume.
+func GetVolumeSnapshotPolicyData(netappClient *netapp.Client) (r map[[2]string]string, err error) {
+
+	var l struct {
+		VolumeAttributes []volumeSnapshotPolicyInfo `xml:"volume-attributes"`
+	}
+	if err = zapiGetIterAttributes(netappClient, "volume-get-iter", nil, &volumeSnapshotPolicyInfo{}, &l); err != nil {
+		return
+	}
+
+	r = make(map[[2]string]string)
+	for _, n := range l.VolumeAttributes {
+		if policy := n.VolumeSnapshotAttributes.SnapshotPolicy; policy != "" && policy != "none" {
+			r[[2]string{n.VolumeIDAttributes.OwningVserverName, n.VolumeIDAttributes.Name}] = policy
+		}
+	}
+	return
+}
+
+// GetVolumeSnapshotNameData returns the names of the snapshots of each vserver and volume.
+func GetVolumeSnapshotNameData(netappClient *netapp.Client) (r map[[2]string][]string, err error) {
+
+	var l struct {
+		SnapshotInfo []volumeSnapshotInfo `xml:"snapshot-info"`
+	}
+	if err = zapiGetIterAttributes(netappClient, "snapshot-get-iter", nil, &volumeSnapshotInfo{}, &l); err != nil {
+		return
+	}
+
+	r = make(map[[2]string][]string)
+	for _, n := range l.SnapshotInfo {
+		key := [2]string{n.Vserver, n.Volume}
+		r[key] = append(r[key], n.Name)
+	}
+	return
+}
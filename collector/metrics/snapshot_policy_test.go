@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const snapshotGetIterReply = `<?xml version='1.0' encoding='UTF-8' ?>
+<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'>
+<results status="passed">
+<attributes-list>
+<snapshot-info><name>daily.2020-07-01_0010</name><volume>vol1</volume><vserver>svm1</vserver></snapshot-info>
+<snapshot-info><name>daily.2020-07-02_0010</name><volume>vol1</volume><vserver>svm1</vserver></snapshot-info>
+<snapshot-info><name>hourly.2020-07-02_1305</name><volume>vol2</volume><vserver>svm1</vserver></snapshot-info>
+</attributes-list>
+<num-records>3</num-records>
+</results>
+</netapp>`
+
+func TestGetVolumeSnapshotNameData(t *testing.T) {
+	netappClient, stop := newZapiTestClient(map[string]string{"snapshot-get-iter": snapshotGetIterReply})
+	defer stop()
+
+	names, err := GetVolumeSnapshotNameData(netappClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[[2]string][]string{
+		{"svm1", "vol1"}: {"daily.2020-07-01_0010", "daily.2020-07-02_0010"},
+		{"svm1", "vol2"}: {"hourly.2020-07-02_1305"},
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("got %v, expected %v", names, expected)
+	}
+}
+
+// TestScrapeSnapshotPolicySnapshotsFail checks the scrape fails when the
+// snapshots can't be listed, rather than reporting the volumes not compliant.
+func TestScrapeSnapshotPolicySnapshotsFail(t *testing.T) {
+	netappClient, stop := newZapiTestClient(map[string]string{
+		"snapshot-policy-get-iter": `<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'><results status="passed"><num-records>0</num-records></results></netapp>`,
+		"volume-get-iter":          `<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'><results status="passed"><num-records>0</num-records></results></netapp>`,
+	})
+	defer stop()
+
+	ch := make(chan prometheus.Metric, 100)
+	if err := (ScrapeSnapshotPolicy{}).Scrape(netappClient, ch); err == nil {
+		t.Error("expected an error for the failed snapshot-get-iter")
+	}
+}
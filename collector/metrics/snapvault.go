@@ -0,0 +1,235 @@
+package metrics
+
+import (
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	SnapvaultSubsystem = "snapvault"
+	SvmDrSubsystem     = "svm_dr"
+)
+
+// Metric descriptors.
+var (
+	snapvaultLabels   = append(variables.BaseLabelNames, "source_location", "destination_location", "destination_vserver", "destination_volume")
+	snapvaultInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SnapvaultSubsystem, "info"),
+		"Information of the vault relationship, policy_type is vault or mirror_vault.",
+		append(snapvaultLabels, "policy", "policy_type", "mirror_state", "relationship_status"), nil)
+	snapvaultNewestSnapshotDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SnapvaultSubsystem, "newest_snapshot_timestamp_seconds"),
+		"Creation time of the newest snapshot vaulted to the destination.",
+		snapvaultLabels, nil)
+	snapvaultIsHealthyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SnapvaultSubsystem, "is_healthy"),
+		"whether the vault relationship is healthy.",
+		snapvaultLabels, nil)
+	snapvaultPolicyRetentionDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SnapvaultSubsystem, "policy_retention"),
+		"Number of snapshots with the SnapMirror label the vault policy keeps on the destination.",
+		append(variables.BaseLabelNames, "vserver", "policy", "snapmirror_label"), nil)
+
+	svmDrLabels               = append(variables.BaseLabelNames, "source_location", "destination_vserver")
+	svmDrIdentityPreserveDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SvmDrSubsystem, "identity_preserve"),
+		"whether the configuration of the source vserver, including its network, is replicated to the destination vserver.",
+		svmDrLabels, nil)
+	svmDrIsHealthyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SvmDrSubsystem, "is_healthy"),
+		"whether the SVM-DR relationship is healthy.",
+		svmDrLabels, nil)
+	svmDrFailoverReadyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SvmDrSubsystem, "failover_ready"),
+		"whether the destination vserver can take over, i.e. the relationship is healthy, snapmirrored and idle.",
+		svmDrLabels, nil)
+	svmDrLagTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, SvmDrSubsystem, "lag_time_seconds"),
+		"Time since the newest snapshot replicated to the destination vserver was created.",
+		svmDrLabels, nil)
+)
+
+// ScrapeSnapvault collects SnapVault, SVM-DR and consistency group relationship
+// info, which are all read from the SnapMirror relationships.
+type ScrapeSnapvault struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeSnapvault) Name() string {
+	return SnapvaultSubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeSnapvault) Help() string {
+	return "Collect Netapp SnapVault, SVM-DR and consistency group relationship info;"
+}
+
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeSnapvault) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 30}
+}
+
+type SnapmirrorRelationship struct {
+	SourceLocation          string
+	DestinationLocation     string
+	DestinationVserver      string
+	DestinationVolume       string
+	RelationshipType        string
+	Policy                  string
+	PolicyType              string
+	MirrorState             string
+	RelationshipStatus      string
+	IsHealthy               bool
+	IdentityPreserve        bool
+	NewestSnapshotTimestamp int64
+	LagTime                 *float64
+	RelationshipGroupType   string
+	CgItemMappings          []string
+}
+
+type SnapmirrorPolicyRule struct {
+	Vserver         string
+	Policy          string
+	PolicyType      string
+	SnapmirrorLabel string
+	Keep            int
+}
+
+type snapmirrorInfo struct {
+	SourceLocation          string   `xml:"source-location"`
+	DestinationLocation     string   `xml:"destination-location"`
+	DestinationVserver      string   `xml:"destination-vserver"`
+	DestinationVolume       string   `xml:"destination-volume"`
+	RelationshipType        string   `xml:"relationship-type"`
+	Policy                  string   `xml:"policy"`
+	PolicyType              string   `xml:"policy-type"`
+	MirrorState             string   `xml:"mirror-state"`
+	RelationshipStatus      string   `xml:"relationship-status"`
+	IsHealthy               bool     `xml:"is-healthy"`
+	IdentityPreserve        bool     `xml:"identity-preserve"`
+	NewestSnapshotTimestamp int64    `xml:"newest-snapshot-timestamp"`
+	LagTime                 *float64 `xml:"lag-time"`
+	RelationshipGroupType   string   `xml:"relationship-group-type"`
+	CgItemMappings          []string `xml:"cg-item-mappings>string"`
+}
+
+type snapmirrorPolicyInfo struct {
+	VserverName string `xml:"vserver-name"`
+	PolicyName  string `xml:"policy-name"`
+	Type        string `xml:"type"`
+	Rules       []struct {
+		SnapmirrorLabel string `xml:"snapmirror-label"`
+		Keep            int    `xml:"keep"`
+	} `xml:"snapmirror-policy-rules>snapmirror-policy-rule-info"`
+}
+
+// Scrape collects data from  netapp SnapVault and SVM-DR info
+func (ScrapeSnapvault) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	relationships, err := GetSnapmirrorData(netappClient)
+	if err != nil {
+		return err
+	}
+	scrapeConsistencyGroups(netappClient, relationships, ch)
+	vaultPolicies := make(map[string]bool)
+	for _, relationship := range relationships {
+		switch {
+		case isConsistencyGroup(relationship):
+			continue
+		case isSvmDr(relationship):
+			svmDrLabelValues := append(variables.BaseLabelValues, relationship.SourceLocation, relationship.DestinationVserver)
+			ch <- prometheus.MustNewConstMetric(svmDrIdentityPreserveDesc, prometheus.GaugeValue, utils.BoolToFloat64(relationship.IdentityPreserve), svmDrLabelValues...)
+			ch <- prometheus.MustNewConstMetric(svmDrIsHealthyDesc, prometheus.GaugeValue, utils.BoolToFloat64(relationship.IsHealthy), svmDrLabelValues...)
+			failoverReady := relationship.IsHealthy && relationship.MirrorState == "snapmirrored" && relationship.RelationshipStatus == "idle"
+			ch <- prometheus.MustNewConstMetric(svmDrFailoverReadyDesc, prometheus.GaugeValue, utils.BoolToFloat64(failoverReady), svmDrLabelValues...)
+			if relationship.LagTime != nil {
+				ch <- prometheus.MustNewConstMetric(svmDrLagTimeDesc, prometheus.GaugeValue, *relationship.LagTime, svmDrLabelValues...)
+			}
+		case isVault(relationship):
+			snapvaultLabelValues := append(variables.BaseLabelValues, relationship.SourceLocation, relationship.DestinationLocation, relationship.DestinationVserver, relationship.DestinationVolume)
+			ch <- prometheus.MustNewConstMetric(snapvaultInfoDesc, prometheus.GaugeValue, 1, append(snapvaultLabelValues, relationship.Policy, relationship.PolicyType, relationship.MirrorState, relationship.RelationshipStatus)...)
+			ch <- prometheus.MustNewConstMetric(snapvaultIsHealthyDesc, prometheus.GaugeValue, utils.BoolToFloat64(relationship.IsHealthy), snapvaultLabelValues...)
+			if relationship.NewestSnapshotTimestamp > 0 {
+				ch <- prometheus.MustNewConstMetric(snapvaultNewestSnapshotDesc, prometheus.GaugeValue, float64(relationship.NewestSnapshotTimestamp), snapvaultLabelValues...)
+			}
+			vaultPolicies[relationship.Policy] = true
+		}
+	}
+
+	rules, err := GetSnapmirrorPolicyRuleData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if rule.PolicyType == "vault" || rule.PolicyType == "mirror_vault" || vaultPolicies[rule.Policy] {
+			ch <- prometheus.MustNewConstMetric(snapvaultPolicyRetentionDesc, prometheus.GaugeValue, float64(rule.Keep), append(variables.BaseLabelValues, rule.Vserver, rule.Policy, rule.SnapmirrorLabel)...)
+		}
+	}
+	return nil
+}
+
+// isSvmDr reports whether the relationship replicates a whole vserver, its
+// locations are then of the form "vserver:".
+func isSvmDr(relationship *SnapmirrorRelationship) bool {
+	return relationship.DestinationVolume == "" && relationship.DestinationVserver != "" && !isConsistencyGroup(relationship)
+}
+
+func isVault(relationship *SnapmirrorRelationship) bool {
+	return relationship.RelationshipType == "vault" || relationship.PolicyType == "vault" || relationship.PolicyType == "mirror_vault"
+}
+
+func GetSnapmirrorData(netappClient *netapp.Client) (r []*SnapmirrorRelationship, err error) {
+
+	var l struct {
+		SnapmirrorInfo []snapmirrorInfo `xml:"snapmirror-info"`
+	}
+	if err = zapiGetIter(netappClient, "snapmirror-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.SnapmirrorInfo {
+		r = append(r, &SnapmirrorRelationship{
+			SourceLocation:          n.SourceLocation,
+			DestinationLocation:     n.DestinationLocation,
+			DestinationVserver:      n.DestinationVserver,
+			DestinationVolume:       n.DestinationVolume,
+			RelationshipType:        n.RelationshipType,
+			Policy:                  n.Policy,
+			PolicyType:              n.PolicyType,
+			MirrorState:             n.MirrorState,
+			RelationshipStatus:      n.RelationshipStatus,
+			IsHealthy:               n.IsHealthy,
+			IdentityPreserve:        n.IdentityPreserve,
+			NewestSnapshotTimestamp: n.NewestSnapshotTimestamp,
+			LagTime:                 n.LagTime,
+			RelationshipGroupType:   n.RelationshipGroupType,
+			CgItemMappings:          n.CgItemMappings,
+		})
+	}
+	return
+}
+
+func GetSnapmirrorPolicyRuleData(netappClient *netapp.Client) (r []*SnapmirrorPolicyRule, err error) {
+
+	var l struct {
+		SnapmirrorPolicyInfo []snapmirrorPolicyInfo `xml:"snapmirror-policy-info"`
+	}
+	if err = zapiGetIter(netappClient, "snapmirror-policy-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.SnapmirrorPolicyInfo {
+		for _, rule := range n.Rules {
+			r = append(r, &SnapmirrorPolicyRule{
+				Vserver:         n.VserverName,
+				Policy:          n.PolicyName,
+				PolicyType:      n.Type,
+				SnapmirrorLabel: rule.SnapmirrorLabel,
+				Keep:            rule.Keep,
+			})
+		}
+	}
+	return
+}
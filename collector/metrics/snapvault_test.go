@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const snapmirrorGetIterReply = `<?xml version='1.0' encoding='UTF-8' ?>
+<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'>
+<results status="passed">
+<attributes-list>
+<snapmirror-info><cg-item-mappings><string>vol1:@vol1_dst</string></cg-item-mappings><destination-location>svm2:/cg/cg1_dst</destination-location><destination-vserver>svm2</destination-vserver><is-healthy>true</is-healthy><mirror-state>snapmirrored</mirror-state><policy>AutomatedFailOver</policy><relationship-group-type>consistencygroup</relationship-group-type><relationship-status>in_sync</relationship-status><source-location>svm1:/cg/cg1</source-location></snapmirror-info>
+</attributes-list>
+<num-records>1</num-records>
+</results>
+</netapp>`
+
+const snapmirrorPolicyGetIterEmptyReply = `<?xml version='1.0' encoding='UTF-8' ?>
+<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'>
+<results status="passed">
+<num-records>0</num-records>
+</results>
+</netapp>`
+
+// TestScrapeSnapvaultConsistencyGroup checks the consistency group
+// relationships are exported with their volumes by the snapvault scraper, and
+// are neither exported as SVM-DR relationships.
+func TestScrapeSnapvaultConsistencyGroup(t *testing.T) {
+	netappClient, stop := newZapiTestClient(map[string]string{
+		"snapmirror-get-iter":        snapmirrorGetIterReply,
+		"snapmirror-policy-get-iter": snapmirrorPolicyGetIterEmptyReply,
+	})
+	defer stop()
+
+	expected := `
+# HELP netapp_consistency_group_is_healthy whether the consistency group relationship is healthy.
+# TYPE netapp_consistency_group_is_healthy gauge
+netapp_consistency_group_is_healthy{cluster="",destination_location="svm2:/cg/cg1_dst",destination_vserver="svm2",group="",source_location="svm1:/cg/cg1"} 1
+# HELP netapp_consistency_group_volume_info Membership of the volume in the consistency group, with the destination volume it is replicated to.
+# TYPE netapp_consistency_group_volume_info gauge
+netapp_consistency_group_volume_info{cluster="",destination_location="svm2:/cg/cg1_dst",destination_volume="vol1_dst",destination_vserver="svm2",group="",source_location="svm1:/cg/cg1",source_volume="vol1"} 1
+`
+	c := scraperCollector{t: t, scraper: ScrapeSnapvault{}, netappClient: netappClient}
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected), "netapp_consistency_group_is_healthy", "netapp_consistency_group_volume_info", "netapp_svm_dr_is_healthy"); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestSnapmirrorRelationshipKind checks an SVM-DR relationship with a
+// mirror_vault policy is both, and a consistency group relationship neither.
+func TestSnapmirrorRelationshipKind(t *testing.T) {
+	tests := []struct {
+		name         string
+		relationship SnapmirrorRelationship
+		svmDr        bool
+		vault        bool
+	}{
+		{"svm-dr with mirror_vault policy", SnapmirrorRelationship{DestinationVserver: "svm2", RelationshipType: "extended_data_protection", PolicyType: "mirror_vault"}, true, true},
+		{"volume vault", SnapmirrorRelationship{DestinationVserver: "svm2", DestinationVolume: "vol1_dst", RelationshipType: "extended_data_protection", PolicyType: "vault"}, false, true},
+		{"volume mirror", SnapmirrorRelationship{DestinationVserver: "svm2", DestinationVolume: "vol1_dst", RelationshipType: "extended_data_protection", PolicyType: "async_mirror"}, false, false},
+		{"consistency group", SnapmirrorRelationship{DestinationVserver: "svm2", RelationshipGroupType: "consistencygroup", PolicyType: "sync_mirror"}, false, false},
+	}
+	for _, test := range tests {
+		if svmDr := isSvmDr(&test.relationship); svmDr != test.svmDr {
+			t.Errorf("%s: isSvmDr = %v, expected %v", test.name, svmDr, test.svmDr)
+		}
+		if vault := isVault(&test.relationship); vault != test.vault {
+			t.Errorf("%s: isVault = %v, expected %v", test.name, vault, test.vault)
+		}
+	}
+}
+
+const (
+	snapmirrorSvmDrGetIterReply = `<?xml version='1.0' encoding='UTF-8' ?>
+<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'>
+<results status="passed">
+<attributes-list>
+<snapmirror-info><destination-location>svm2:</destination-location><destination-vserver>svm2</destination-vserver><identity-preserve>true</identity-preserve><is-healthy>true</is-healthy><mirror-state>snapmirrored</mirror-state><policy>MirrorAllSnapshots</policy><policy-type>async_mirror</policy-type><relationship-status>idle</relationship-status><source-location>svm1:</source-location></snapmirror-info>
+<snapmirror-info><destination-location>svm4:</destination-location><destination-vserver>svm4</destination-vserver><identity-preserve>false</identity-preserve><is-healthy>true</is-healthy><mirror-state>snapmirrored</mirror-state><policy>MirrorAllSnapshots</policy><policy-type>async_mirror</policy-type><relationship-status>transferring</relationship-status><source-location>svm3:</source-location></snapmirror-info>
+<snapmirror-info><destination-location>svm2:vol1_dst</destination-location><destination-volume>vol1_dst</destination-volume><destination-vserver>svm2</destination-vserver><is-healthy>true</is-healthy><mirror-state>snapmirrored</mirror-state><policy>backup</policy><policy-type>vault</policy-type><relationship-status>idle</relationship-status><source-location>svm1:vol1</source-location></snapmirror-info>
+</attributes-list>
+<num-records>3</num-records>
+</results>
+</netapp>`
+	snapmirrorPolicyGetIterReply = `<?xml version='1.0' encoding='UTF-8' ?>
+<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'>
+<results status="passed">
+<attributes-list>
+<snapmirror-policy-info><policy-name>backup</policy-name><type>vault</type><vserver-name>svm2</vserver-name><snapmirror-policy-rules><snapmirror-policy-rule-info><keep>7</keep><snapmirror-label>daily</snapmirror-label></snapmirror-policy-rule-info></snapmirror-policy-rules></snapmirror-policy-info>
+<snapmirror-policy-info><policy-name>MirrorAllSnapshots</policy-name><type>async_mirror</type><vserver-name>cluster1</vserver-name><snapmirror-policy-rules><snapmirror-policy-rule-info><keep>1</keep><snapmirror-label>sm_created</snapmirror-label></snapmirror-policy-rule-info></snapmirror-policy-rules></snapmirror-policy-info>
+</attributes-list>
+<num-records>2</num-records>
+</results>
+</netapp>`
+)
+
+// TestScrapeSnapvault checks an SVM-DR relationship is failover ready only
+// when healthy, snapmirrored and idle, and that only the rules of vault
+// policies are exported as retention.
+func TestScrapeSnapvault(t *testing.T) {
+	netappClient, stop := newZapiTestClient(map[string]string{
+		"snapmirror-get-iter":        snapmirrorSvmDrGetIterReply,
+		"snapmirror-policy-get-iter": snapmirrorPolicyGetIterReply,
+	})
+	defer stop()
+
+	expected := `
+# HELP netapp_snapvault_policy_retention Number of snapshots with the SnapMirror label the vault policy keeps on the destination.
+# TYPE netapp_snapvault_policy_retention gauge
+netapp_snapvault_policy_retention{cluster="",group="",policy="backup",snapmirror_label="daily",vserver="svm2"} 7
+# HELP netapp_svm_dr_failover_ready whether the destination vserver can take over, i.e. the relationship is healthy, snapmirrored and idle.
+# TYPE netapp_svm_dr_failover_ready gauge
+netapp_svm_dr_failover_ready{cluster="",destination_vserver="svm2",group="",source_location="svm1:"} 1
+netapp_svm_dr_failover_ready{cluster="",destination_vserver="svm4",group="",source_location="svm3:"} 0
+`
+	c := scraperCollector{t: t, scraper: ScrapeSnapvault{}, netappClient: netappClient}
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected), "netapp_snapvault_policy_retention", "netapp_svm_dr_failover_ready"); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,10 +1,8 @@
 package metrics
 
 import (
-	"log"
-
-	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
 	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
 	"github.com/pepabo/go-netapp/netapp"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -16,11 +14,31 @@ const (
 
 // Metric descriptors.
 var (
-	storageDiskLabels       = append(variables.BaseLabelNames, "disk","node", "type","model")
+	storageDiskLabels          = append(variables.BaseLabelNames, "disk", "node", "type", "model")
 	storageDiskHealthStateDesc = prometheus.NewDesc(
 		prometheus.BuildFQName(variables.Namespace, StorageDiskSubsystem, "is_failed"),
 		"if this disk is failed.",
 		storageDiskLabels, nil)
+	storageDiskInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, StorageDiskSubsystem, "info"),
+		"Information of the disk, container_type is one of aggregate, spare, broken, unassigned, shared, etc.",
+		append(storageDiskLabels, "container_type", "firmware_revision", "serial_number", "shelf", "bay"), nil)
+	storageDiskPowerOnHoursDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, StorageDiskSubsystem, "power_on_hours"),
+		"Power on hours of the disk.",
+		storageDiskLabels, nil)
+	storageDiskPercentRatedLifeUsedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, StorageDiskSubsystem, "percent_rated_life_used"),
+		"Percent of rated life used of the SSD.",
+		storageDiskLabels, nil)
+	storageDiskUsableSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, StorageDiskSubsystem, "usable_size_bytes"),
+		"Usable (right-sized) size of the disk in bytes.",
+		storageDiskLabels, nil)
+	storageDiskPhysicalSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, StorageDiskSubsystem, "physical_size_bytes"),
+		"Physical size of the disk in bytes.",
+		storageDiskLabels, nil)
 )
 
 // Scrapesystem collects system node info
@@ -36,65 +54,115 @@ func (ScrapeStorageDisk) Help() string {
 	return "Collect Netapp storage disk info;"
 }
 
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeStorageDisk) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 20}
+}
+
 type StorageDisk struct {
-	DiskName            string
-	DiskType            string
-	Model               string
-	IsFailed            *bool
-	HomeNodeName        string 
+	DiskName             string
+	DiskType             string
+	Model                string
+	IsFailed             *bool
+	HomeNodeName         string
+	ContainerType        string
+	FirmwareRevision     string
+	SerialNumber         string
+	Shelf                string
+	ShelfBay             string
+	PowerOnTimeInterval  *float64
+	PercentRatedLifeUsed *float64
+	UsableSize           float64
+	PhysicalSize         float64
 }
 
+// storageDiskInfo is the storage-disk-info record, go-netapp misses the raid,
+// stats and ssd attributes of it.
+type storageDiskInfo struct {
+	DiskName          string `xml:"disk-name"`
+	DiskInventoryInfo struct {
+		BytesPerSector   float64 `xml:"bytes-per-sector"`
+		CapacitySectors  float64 `xml:"capacity-sectors"`
+		RightSizeSectors float64 `xml:"right-size-sectors"`
+		DiskType         string  `xml:"disk-type"`
+		FirmwareRevision string  `xml:"firmware-revision"`
+		Model            string  `xml:"model"`
+		SerialNumber     string  `xml:"serial-number"`
+		Shelf            string  `xml:"shelf"`
+		ShelfBay         string  `xml:"shelf-bay"`
+	} `xml:"disk-inventory-info"`
+	DiskOwnershipInfo struct {
+		HomeNodeName string `xml:"home-node-name"`
+		IsFailed     *bool  `xml:"is-failed"`
+	} `xml:"disk-ownership-info"`
+	DiskRaidInfo struct {
+		ContainerType string `xml:"container-type"`
+	} `xml:"disk-raid-info"`
+	DiskStatsInfo struct {
+		PowerOnTimeInterval *float64 `xml:"power-on-time-interval"`
+	} `xml:"disk-stats-info"`
+	DiskSsdInfo struct {
+		PercentRatedLifeUsed *float64 `xml:"percent-rated-life-used"`
+	} `xml:"disk-ssd-info"`
+}
 
 // Scrape collects data from  netapp StorageDisk info
 func (ScrapeStorageDisk) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
 
-	for _, storageDiskInfo := range GetStorageDiskData(netappClient) {
-		storageDiskLabelValues := append(variables.BaseLabelValues, storageDiskInfo.DiskName,storageDiskInfo.HomeNodeName,storageDiskInfo.DiskType,storageDiskInfo.Model)
-		ch <- prometheus.MustNewConstMetric(storageDiskHealthStateDesc, prometheus.GaugeValue, utils.BoolToFloat64(*storageDiskInfo.IsFailed), storageDiskLabelValues...)
-	
+	storageDisks, err := GetStorageDiskData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, storageDiskInfo := range storageDisks {
+		storageDiskLabelValues := append(variables.BaseLabelValues, storageDiskInfo.DiskName, storageDiskInfo.HomeNodeName, storageDiskInfo.DiskType, storageDiskInfo.Model)
+		if storageDiskInfo.IsFailed != nil {
+			ch <- prometheus.MustNewConstMetric(storageDiskHealthStateDesc, prometheus.GaugeValue, utils.BoolToFloat64(*storageDiskInfo.IsFailed), storageDiskLabelValues...)
+		}
+		ch <- prometheus.MustNewConstMetric(storageDiskInfoDesc, prometheus.GaugeValue, 1, append(storageDiskLabelValues, storageDiskInfo.ContainerType, storageDiskInfo.FirmwareRevision, storageDiskInfo.SerialNumber, storageDiskInfo.Shelf, storageDiskInfo.ShelfBay)...)
+		if storageDiskInfo.PowerOnTimeInterval != nil {
+			ch <- prometheus.MustNewConstMetric(storageDiskPowerOnHoursDesc, prometheus.GaugeValue, *storageDiskInfo.PowerOnTimeInterval/3600, storageDiskLabelValues...)
+		}
+		if storageDiskInfo.PercentRatedLifeUsed != nil {
+			ch <- prometheus.MustNewConstMetric(storageDiskPercentRatedLifeUsedDesc, prometheus.GaugeValue, *storageDiskInfo.PercentRatedLifeUsed, storageDiskLabelValues...)
+		}
+		if storageDiskInfo.UsableSize > 0 {
+			ch <- prometheus.MustNewConstMetric(storageDiskUsableSizeDesc, prometheus.GaugeValue, storageDiskInfo.UsableSize, storageDiskLabelValues...)
+		}
+		if storageDiskInfo.PhysicalSize > 0 {
+			ch <- prometheus.MustNewConstMetric(storageDiskPhysicalSizeDesc, prometheus.GaugeValue, storageDiskInfo.PhysicalSize, storageDiskLabelValues...)
+		}
 	}
 
 	return nil
 }
 
-func GetStorageDiskData(netappClient *netapp.Client) (r []*StorageDisk) {
-	
-	ff := new(bool)
-	*ff = false
+func GetStorageDiskData(netappClient *netapp.Client) (r []*StorageDisk, err error) {
 
-	opts := &netapp.StorageDiskOptions{
-		Query: &netapp.StorageDiskInfo{},
-		DesiredAttributes: &netapp.StorageDiskInfo{
-			DiskName: "x",
-			DiskInventoryInfo:  &netapp.DiskInventoryInfo{
-				DiskType: "x",
-				Model: "x",
-			},
-			DiskOwnershipInfo: &netapp.DiskOwnershipInfo{
-				HomeNodeName: "x",
-				IsFailed: ff,
-			},
-		},
+	var l struct {
+		StorageDiskInfo []storageDiskInfo `xml:"storage-disk-info"`
 	}
-
-
-
-
-	res,_,err := netappClient.StorageDisk.StorageDiskGetIter(opts) 
-	if err !=nil  {
-		log.Fatalf("error when getting storage disks, %s",err)
+	if err = zapiGetIter(netappClient, "storage-disk-get-iter", nil, &l); err != nil {
+		return
 	}
 
-	for _, n := range res.Results.AttributesList.StorageDiskInfo {
+	for _, n := range l.StorageDiskInfo {
+		inventory := n.DiskInventoryInfo
 		r = append(r, &StorageDisk{
-			DiskName:                n.DiskName,
-			DiskType:							   n.DiskInventoryInfo.DiskType,
-			Model:									 n.DiskInventoryInfo.Model,
-			HomeNodeName:						 n.DiskOwnershipInfo.HomeNodeName,
-			IsFailed:								 n.DiskOwnershipInfo.IsFailed,
+			DiskName:             n.DiskName,
+			DiskType:             inventory.DiskType,
+			Model:                inventory.Model,
+			HomeNodeName:         n.DiskOwnershipInfo.HomeNodeName,
+			IsFailed:             n.DiskOwnershipInfo.IsFailed,
+			ContainerType:        n.DiskRaidInfo.ContainerType,
+			FirmwareRevision:     inventory.FirmwareRevision,
+			SerialNumber:         inventory.SerialNumber,
+			Shelf:                inventory.Shelf,
+			ShelfBay:             inventory.ShelfBay,
+			PowerOnTimeInterval:  n.DiskStatsInfo.PowerOnTimeInterval,
+			PercentRatedLifeUsed: n.DiskSsdInfo.PercentRatedLifeUsed,
+			UsableSize:           inventory.RightSizeSectors * inventory.BytesPerSector,
+			PhysicalSize:         inventory.CapacitySectors * inventory.BytesPerSector,
 		})
 	}
 	return
 }
-
-
@@ -1,10 +1,14 @@
 package metrics
 
 import (
+	"encoding/xml"
 	"log"
+	"math"
+	"sync"
+	"time"
 
-	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
 	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
 	"github.com/pepabo/go-netapp/netapp"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -33,8 +37,78 @@ var (
 		prometheus.BuildFQName(variables.Namespace, SystemSubsystem, "over_temperature"),
 		"Over Temperature of the node.",
 		systemLabels, nil)
+	systemNodeInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, "node", "info"),
+		"Information of the node, value is always 1.",
+		append(systemLabels, "version", "model", "serial_number", "system_id", "vendor", "uuid", "owner"), nil)
+	systemNodeBootTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, "node", "boot_timestamp_seconds"),
+		"Boot time of the node, derived from its uptime.",
+		systemLabels, nil)
+	systemNodeNvramBatteryStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, "node", "nvram_battery_status"),
+		"Status of the NVRAM battery of the node, 1(battery_ok), 0(battery_unknown), 2(battery_partially_discharged), 3(battery_fully_discharged), 4(battery_not_present), 5(battery_near_end_of_life), 6(battery_at_end_of_life), 7(battery_over_charged), or 8(battery_fully_charged).",
+		systemLabels, nil)
+	systemNodeHealthyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, "node", "is_healthy"),
+		"whether the node is healthy.",
+		systemLabels, nil)
+	systemNodeEpsilonDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, "node", "is_epsilon"),
+		"whether the node holds epsilon.",
+		systemLabels, nil)
+	systemNodeEligibleDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, "node", "is_eligible"),
+		"whether the node is eligible to participate in the cluster.",
+		systemLabels, nil)
+	systemNodeCpusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, "node", "cpus"),
+		"Number of processors of the node.",
+		systemLabels, nil)
+	systemNodeMemoryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, "node", "memory_bytes"),
+		"Memory size of the node in bytes.",
+		systemLabels, nil)
 )
 
+var systemNodeNvramBatteryStatus = map[string]float64{
+	"battery_unknown":              0,
+	"battery_ok":                   1,
+	"battery_partially_discharged": 2,
+	"battery_fully_discharged":     3,
+	"battery_not_present":          4,
+	"battery_near_end_of_life":     5,
+	"battery_at_end_of_life":       6,
+	"battery_over_charged":         7,
+	"battery_fully_charged":        8,
+}
+
+// nodeBootTimeSlack is how far the boot time derived from the uptime may move
+// between scrapes, due to the latency of the request, before it is taken as a
+// reboot.
+const nodeBootTimeSlack = 60
+
+var (
+	nodeBootTimesMu sync.Mutex
+	nodeBootTimes   = make(map[string]float64)
+)
+
+// nodeBootTime returns the boot time of the node of target given its uptime,
+// the boot time reported before is kept unless the node rebooted, so that the
+// boot time only changes on a reboot.
+func nodeBootTime(target, node string, uptime float64) float64 {
+	bootTime := math.Floor(float64(time.Now().Unix()) - uptime)
+	key := target + "/" + node
+
+	nodeBootTimesMu.Lock()
+	defer nodeBootTimesMu.Unlock()
+	if last, ok := nodeBootTimes[key]; ok && math.Abs(bootTime-last) <= nodeBootTimeSlack {
+		return last
+	}
+	nodeBootTimes[key] = bootTime
+	return bootTime
+}
+
 // Scrapesystem collects system node info
 type ScrapeSystem struct{}
 
@@ -48,6 +122,11 @@ func (ScrapeSystem) Help() string {
 	return "Collect Netapp System and Node info;"
 }
 
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeSystem) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 20}
+}
+
 type Node struct {
 	Name                      string
 	OwnerName                 string
@@ -58,47 +137,115 @@ type Node struct {
 	EnvFailedFanCount         int
 	EnvFailedPowerSupplyCount int
 	EnvOverTemperature        bool
+	SerialNumber              string
+	SystemId                  string
+	Vendor                    string
+	ProductVersion            string
+	NvramBatteryStatus        string
+	// Health is nil if the filer doesn't report the health flags of the node.
+	Health *NodeHealth
+}
+
+type NodeHealth struct {
+	Name           string
+	IsNodeHealthy  bool
+	IsEpsilonNode  bool
+	IsNodeEligible bool
+}
+
+type NodeSystemInfo struct {
+	Name               string
+	NumberOfProcessors float64
+	MemorySize         float64
+}
+
+// nodeDetailsInfo is both the desired attributes and the record of
+// system-node-get-iter, with the health flags go-netapp misses.
+type nodeDetailsInfo struct {
+	XMLName                   xml.Name `xml:"node-details-info"`
+	Node                      string   `xml:"node"`
+	NodeOwner                 string   `xml:"node-owner"`
+	NodeModel                 string   `xml:"node-model"`
+	NodeLocation              string   `xml:"node-location"`
+	NodeUuid                  string   `xml:"node-uuid"`
+	NodeUptime                string   `xml:"node-uptime"`
+	EnvFailedFanCount         int      `xml:"env-failed-fan-count"`
+	EnvFailedPowerSupplyCount int      `xml:"env-failed-power-supply-count"`
+	EnvOverTemperature        bool     `xml:"env-over-temperature"`
+	NodeSerialNumber          string   `xml:"node-serial-number"`
+	NodeSystemId              string   `xml:"node-system-id"`
+	NodeVendor                string   `xml:"node-vendor"`
+	ProductVersion            string   `xml:"product-version"`
+	NvramBatteryStatus        string   `xml:"nvram-battery-status"`
+	IsNodeHealthy             string   `xml:"is-node-healthy"`
+	IsEpsilonNode             string   `xml:"is-epsilon-node"`
+	IsNodeEligible            string   `xml:"is-node-eligible"`
+}
+
+// nodeSystemInfo is both the desired attributes and the record of
+// system-get-node-info-iter, memory-size is in MB.
+type nodeSystemInfo struct {
+	XMLName            xml.Name `xml:"system-info"`
+	SystemName         string   `xml:"system-name"`
+	NumberOfProcessors string   `xml:"number-of-processors"`
+	MemorySize         string   `xml:"memory-size"`
 }
 
 // Scrape collects data from  netapp system and node info
 func (ScrapeSystem) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
 
+	// the CPUs and memory are optional, the other node metrics are exported
+	// without them.
+	systemInfos := make(map[string]*NodeSystemInfo)
+	nodeSystemInfos, err := GetNodeSystemInfoData(netappClient)
+	if err != nil {
+		log.Printf("%s", err)
+	}
+	for _, nodeSystemInfo := range nodeSystemInfos {
+		systemInfos[nodeSystemInfo.Name] = nodeSystemInfo
+	}
+
 	for _, NodeInfo := range GetNodeData(netappClient) {
 		systemLabelValues := append(variables.BaseLabelValues, NodeInfo.Name, NodeInfo.Location)
 		if uptime, ok := utils.ParseStatus(NodeInfo.Uptime); ok {
 			ch <- prometheus.MustNewConstMetric(systemNodeUptimeDesc, prometheus.GaugeValue, uptime, systemLabelValues...)
+			ch <- prometheus.MustNewConstMetric(systemNodeBootTimeDesc, prometheus.GaugeValue, nodeBootTime(netappClient.BaseURL.Host, NodeInfo.Name, uptime), systemLabelValues...)
+		}
+		if value, ok := systemNodeNvramBatteryStatus[NodeInfo.NvramBatteryStatus]; ok {
+			ch <- prometheus.MustNewConstMetric(systemNodeNvramBatteryStatusDesc, prometheus.GaugeValue, value, systemLabelValues...)
+		}
+		if health := NodeInfo.Health; health != nil {
+			ch <- prometheus.MustNewConstMetric(systemNodeHealthyDesc, prometheus.GaugeValue, utils.BoolToFloat64(health.IsNodeHealthy), systemLabelValues...)
+			ch <- prometheus.MustNewConstMetric(systemNodeEpsilonDesc, prometheus.GaugeValue, utils.BoolToFloat64(health.IsEpsilonNode), systemLabelValues...)
+			ch <- prometheus.MustNewConstMetric(systemNodeEligibleDesc, prometheus.GaugeValue, utils.BoolToFloat64(health.IsNodeEligible), systemLabelValues...)
+		}
+		if systemInfo, ok := systemInfos[NodeInfo.Name]; ok {
+			ch <- prometheus.MustNewConstMetric(systemNodeCpusDesc, prometheus.GaugeValue, systemInfo.NumberOfProcessors, systemLabelValues...)
+			ch <- prometheus.MustNewConstMetric(systemNodeMemoryDesc, prometheus.GaugeValue, systemInfo.MemorySize, systemLabelValues...)
 		}
 		ch <- prometheus.MustNewConstMetric(systemNodeFailedFanCountDesc, prometheus.GaugeValue, float64(NodeInfo.EnvFailedFanCount), systemLabelValues...)
 		ch <- prometheus.MustNewConstMetric(systemNodeFailedPowerSupplyCountDesc, prometheus.GaugeValue, float64(NodeInfo.EnvFailedPowerSupplyCount), systemLabelValues...)
 		ch <- prometheus.MustNewConstMetric(systemNodeOverTemperatureDesc, prometheus.GaugeValue, utils.BoolToFloat64(NodeInfo.EnvOverTemperature), systemLabelValues...)
+		ch <- prometheus.MustNewConstMetric(systemNodeInfoDesc, prometheus.GaugeValue, 1, append(systemLabelValues, NodeInfo.ProductVersion, NodeInfo.Model, NodeInfo.SerialNumber, NodeInfo.SystemId, NodeInfo.Vendor, NodeInfo.Uuid, NodeInfo.OwnerName)...)
 
 	}
 	return nil
 }
 
+// GetNodeData returns the nodes of the cluster, an error is logged and the
+// nodes read before it are returned.
 func GetNodeData(netappClient *netapp.Client) (r []*Node) {
-	opts := &netapp.NodeDetailOptions{
-		Query: &netapp.NodeDetailsQuery{},
-		DesiredAttributes: &netapp.NodeDetailsQuery{
-			NodeDetails: &netapp.NodeDetails{
-				Name:                      "x",
-				NodeOwner:                 "x",
-				NodeModel:                 "x",
-				NodeLocation:              "x",
-				NodeUuid:                  "x",
-				NodeUptime:                "x",
-				EnvFailedFanCount:         1,
-				EnvFailedPowerSupplyCount: 1,
-				EnvOverTemperature:        false,
-			},
-		},
-	}
 
-	l := getNodeList(netappClient, opts)
+	var l struct {
+		NodeDetailsInfo []nodeDetailsInfo `xml:"node-details-info"`
+	}
+	if err := zapiGetIterAttributes(netappClient, "system-node-get-iter", nil, &nodeDetailsInfo{}, &l); err != nil {
+		log.Printf("%s", err)
+	}
 
-	for _, n := range l {
-		r = append(r, &Node{
-			Name:                      n.Name,
+	for _, n := range l.NodeDetailsInfo {
+		node := &Node{
+			Name:                      n.Node,
 			OwnerName:                 n.NodeOwner,
 			Model:                     n.NodeModel,
 			Location:                  n.NodeLocation,
@@ -107,28 +254,42 @@ func GetNodeData(netappClient *netapp.Client) (r []*Node) {
 			EnvFailedFanCount:         n.EnvFailedFanCount,
 			EnvFailedPowerSupplyCount: n.EnvFailedPowerSupplyCount,
 			EnvOverTemperature:        n.EnvOverTemperature,
-		})
+			SerialNumber:              n.NodeSerialNumber,
+			SystemId:                  n.NodeSystemId,
+			Vendor:                    n.NodeVendor,
+			ProductVersion:            parseRelease(n.ProductVersion),
+			NvramBatteryStatus:        n.NvramBatteryStatus,
+		}
+		if n.IsNodeHealthy != "" {
+			node.Health = &NodeHealth{
+				Name:           n.Node,
+				IsNodeHealthy:  n.IsNodeHealthy == "true",
+				IsEpsilonNode:  n.IsEpsilonNode == "true",
+				IsNodeEligible: n.IsNodeEligible == "true",
+			}
+		}
+		r = append(r, node)
 	}
 	return
 }
 
-func getNodeList(netappClient *netapp.Client, opts *netapp.NodeDetailOptions) (r []netapp.NodeDetails) {
+func GetNodeSystemInfoData(netappClient *netapp.Client) (r []*NodeSystemInfo, err error) {
 
-	var pages []*netapp.NodeDetailsResponse
-	handler := func(r netapp.NodeDetailsPagesResponse) bool {
-		if r.Error != nil {
-			log.Printf("%s", r.Error)
-			return false
-		}
-		pages = append(pages, r.Response)
-		return true
+	var l struct {
+		SystemInfo []nodeSystemInfo `xml:"system-info"`
 	}
-
-	netappClient.System.ListPages(opts, handler)
-
-	for _, p := range pages {
-		r = append(r, p.Results.NodeDetails...)
+	if err = zapiGetIterAttributes(netappClient, "system-get-node-info-iter", nil, &nodeSystemInfo{}, &l); err != nil {
+		return
 	}
 
+	for _, n := range l.SystemInfo {
+		numberOfProcessors, _ := utils.ParseStatus(n.NumberOfProcessors)
+		memorySize, _ := utils.ParseStatus(n.MemorySize)
+		r = append(r, &NodeSystemInfo{
+			Name:               n.SystemName,
+			NumberOfProcessors: numberOfProcessors,
+			MemorySize:         memorySize * 1024 * 1024,
+		})
+	}
 	return
 }
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const systemNodeReply = `<?xml version='1.0' encoding='UTF-8' ?>
+<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'>
+<results status="passed">
+<attributes-list>
+<node-details-info><env-failed-fan-count>1</env-failed-fan-count><env-failed-power-supply-count>0</env-failed-power-supply-count><env-over-temperature>false</env-over-temperature><is-epsilon-node>true</is-epsilon-node><is-node-eligible>true</is-node-eligible><is-node-healthy>false</is-node-healthy><node>node-01</node><node-location>dc1</node-location><node-model>AFF-A300</node-model><node-uptime>86400</node-uptime><nvram-battery-status>battery_ok</nvram-battery-status><product-version>NetApp Release 9.7P5: Fri Jun 19 2020</product-version></node-details-info>
+</attributes-list>
+<num-records>1</num-records>
+</results>
+</netapp>`
+
+// TestScrapeSystemWithoutNodeInfo checks the node metrics are exported when
+// the optional system-get-node-info-iter fails.
+func TestScrapeSystemWithoutNodeInfo(t *testing.T) {
+	netappClient, stop := newZapiTestClient(map[string]string{"system-node-get-iter": systemNodeReply})
+	defer stop()
+
+	expected := `
+# HELP netapp_system_uptime uptime of the node.
+# TYPE netapp_system_uptime gauge
+netapp_system_uptime{cluster="",group="",location="dc1",node="node-01"} 86400
+# HELP netapp_system_failed_fan_count Failed Fan Count of the node.
+# TYPE netapp_system_failed_fan_count gauge
+netapp_system_failed_fan_count{cluster="",group="",location="dc1",node="node-01"} 1
+# HELP netapp_node_is_healthy whether the node is healthy.
+# TYPE netapp_node_is_healthy gauge
+netapp_node_is_healthy{cluster="",group="",location="dc1",node="node-01"} 0
+# HELP netapp_node_is_epsilon whether the node holds epsilon.
+# TYPE netapp_node_is_epsilon gauge
+netapp_node_is_epsilon{cluster="",group="",location="dc1",node="node-01"} 1
+`
+	c := scraperCollector{t: t, scraper: ScrapeSystem{}, netappClient: netappClient}
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected), "netapp_system_uptime", "netapp_system_failed_fan_count", "netapp_node_is_healthy", "netapp_node_is_epsilon", "netapp_node_cpus", "netapp_node_memory_bytes"); err != nil {
+		t.Error(err)
+	}
+}
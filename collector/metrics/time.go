@@ -0,0 +1,281 @@
+package metrics
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	TimeSubsystem = "time"
+	NtpSubsystem  = "ntp"
+	DnsSubsystem  = "dns"
+
+	// nodeClockWorkers is the number of nodes whose clock is read at the same time.
+	nodeClockWorkers = 4
+)
+
+// Metric descriptors.
+var (
+	nodeClockOffsetDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, "node", "clock_offset_seconds"),
+		"Clock of the node minus the clock of the exporter; the clock of the node has a resolution of one second, so an offset within ±1s is noise.",
+		append(variables.BaseLabelNames, "node"), nil)
+	nodeClockReadErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, "node", "clock_read_errors"),
+		"Number of nodes whose clock could not be read, their clock offset is not exported.",
+		variables.BaseLabelNames, nil)
+
+	ntpServerLabels   = append(variables.BaseLabelNames, "server")
+	ntpServerInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, NtpSubsystem, "server_info"),
+		"Information of the NTP server configured for the cluster.",
+		append(ntpServerLabels, "version", "is_preferred"), nil)
+	ntpServerReachableDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, NtpSubsystem, "server_is_reachable"),
+		"whether the NTP server is reachable from the node.",
+		append(ntpServerLabels, "node"), nil)
+
+	dnsLabels        = append(variables.BaseLabelNames, "vserver")
+	dnsIsEnabledDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, DnsSubsystem, "is_enabled"),
+		"whether DNS is enabled for the vserver.",
+		dnsLabels, nil)
+	dnsNameServersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, DnsSubsystem, "name_servers"),
+		"Number of name servers configured for the vserver.",
+		dnsLabels, nil)
+	dnsInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, DnsSubsystem, "info"),
+		"DNS configuration of the vserver, domains and name_servers are comma separated.",
+		append(dnsLabels, "domains", "name_servers"), nil)
+)
+
+// ScrapeTime collects the clock offset of the nodes, NTP and DNS info
+type ScrapeTime struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeTime) Name() string {
+	return TimeSubsystem
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeTime) Help() string {
+	return "Collect Netapp node clock offset, NTP and DNS info;"
+}
+
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeTime) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 20}
+}
+
+type NodeClock struct {
+	Node   string
+	Offset float64
+}
+
+type NtpServer struct {
+	Server      string
+	Version     string
+	IsPreferred bool
+}
+
+type NtpServerStatus struct {
+	Node            string
+	Server          string
+	IsPeerReachable bool
+}
+
+type Dns struct {
+	Vserver     string
+	State       string
+	Domains     []string
+	NameServers []string
+}
+
+type clockGetClockResponse struct {
+	XMLName xml.Name `xml:"netapp"`
+	Results struct {
+		UtcTime int64 `xml:"utc-time"`
+	} `xml:"results"`
+}
+
+type ntpServerInfo struct {
+	ServerName  string `xml:"server-name"`
+	Version     string `xml:"version"`
+	IsPreferred bool   `xml:"is-preferred"`
+}
+
+type ntpServerStatusInfo struct {
+	Node            string `xml:"node"`
+	Server          string `xml:"server"`
+	IsPeerReachable bool   `xml:"is-peer-reachable"`
+}
+
+type netDnsInfo struct {
+	VserverName string   `xml:"vserver-name"`
+	DnsState    string   `xml:"dns-state"`
+	Domains     []string `xml:"domains>string"`
+	NameServers []string `xml:"name-servers>ip-address"`
+}
+
+// Scrape collects data from  netapp clock, NTP and DNS info
+func (ScrapeTime) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
+
+	clocks, failed := GetNodeClockData(netappClient)
+	for _, clock := range clocks {
+		ch <- prometheus.MustNewConstMetric(nodeClockOffsetDesc, prometheus.GaugeValue, clock.Offset, append(variables.BaseLabelValues, clock.Node)...)
+	}
+	ch <- prometheus.MustNewConstMetric(nodeClockReadErrorsDesc, prometheus.GaugeValue, float64(len(failed)), variables.BaseLabelValues...)
+
+	ntpServers, err := GetNtpServerData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, ntpServer := range ntpServers {
+		ch <- prometheus.MustNewConstMetric(ntpServerInfoDesc, prometheus.GaugeValue, 1, append(variables.BaseLabelValues, ntpServer.Server, ntpServer.Version, strconv.FormatBool(ntpServer.IsPreferred))...)
+	}
+
+	// the reachability of the NTP servers is known from ONTAP 9.5 on.
+	if zapiSupports(netappClient, utils.ZapiVersion{Major: 1, Minor: 150}) {
+		statuses, err := GetNtpServerStatusData(netappClient)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			ch <- prometheus.MustNewConstMetric(ntpServerReachableDesc, prometheus.GaugeValue, utils.BoolToFloat64(status.IsPeerReachable), append(variables.BaseLabelValues, status.Server, status.Node)...)
+		}
+	}
+
+	dnsConfigs, err := GetDnsData(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, dns := range dnsConfigs {
+		dnsLabelValues := append(variables.BaseLabelValues, dns.Vserver)
+		ch <- prometheus.MustNewConstMetric(dnsIsEnabledDesc, prometheus.GaugeValue, utils.BoolToFloat64(dns.State == "enabled"), dnsLabelValues...)
+		ch <- prometheus.MustNewConstMetric(dnsNameServersDesc, prometheus.GaugeValue, float64(len(dns.NameServers)), dnsLabelValues...)
+		ch <- prometheus.MustNewConstMetric(dnsInfoDesc, prometheus.GaugeValue, 1, append(dnsLabelValues, strings.Join(dns.Domains, ","), strings.Join(dns.NameServers, ","))...)
+	}
+	return nil
+}
+
+// GetNodeClockData returns the clock offset of every node, which is read with
+// clock-get-clock on the node and compared to the middle of the round trip,
+// and the nodes whose clock could not be read.
+func GetNodeClockData(netappClient *netapp.Client) (r []*NodeClock, failed []string) {
+	var mu sync.Mutex
+	nodes := make(chan string)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < nodeClockWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for node := range nodes {
+				clock, err := getNodeClock(netappClient, node)
+				mu.Lock()
+				if err != nil {
+					log.Printf("clock-get-clock on %s: %s", node, err)
+					failed = append(failed, node)
+				} else {
+					r = append(r, clock)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, node := range GetNodeData(netappClient) {
+		nodes <- node.Name
+	}
+	close(nodes)
+	wg.Wait()
+	return
+}
+
+func getNodeClock(netappClient *netapp.Client, node string) (*NodeClock, error) {
+	api := struct {
+		XMLName xml.Name `xml:"clock-get-clock"`
+	}{}
+	var res clockGetClockResponse
+	start := time.Now()
+	if err := zapiInvokeNode(netappClient, node, &api, &res); err != nil {
+		return nil, err
+	}
+	end := time.Now()
+	if res.Results.UtcTime == 0 {
+		return nil, fmt.Errorf("no utc-time")
+	}
+	middle := start.Add(end.Sub(start) / 2)
+	return &NodeClock{
+		Node:   node,
+		Offset: float64(res.Results.UtcTime) - float64(middle.UnixNano())/1e9,
+	}, nil
+}
+
+func GetNtpServerData(netappClient *netapp.Client) (r []*NtpServer, err error) {
+
+	var l struct {
+		NtpServerInfo []ntpServerInfo `xml:"ntp-server-info"`
+	}
+	if err = zapiGetIter(netappClient, "ntp-server-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.NtpServerInfo {
+		r = append(r, &NtpServer{
+			Server:      n.ServerName,
+			Version:     n.Version,
+			IsPreferred: n.IsPreferred,
+		})
+	}
+	return
+}
+
+func GetNtpServerStatusData(netappClient *netapp.Client) (r []*NtpServerStatus, err error) {
+
+	var l struct {
+		NtpServerStatusInfo []ntpServerStatusInfo `xml:"ntp-server-status-info"`
+	}
+	if err = zapiGetIter(netappClient, "ntp-server-status-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.NtpServerStatusInfo {
+		r = append(r, &NtpServerStatus{
+			Node:            n.Node,
+			Server:          n.Server,
+			IsPeerReachable: n.IsPeerReachable,
+		})
+	}
+	return
+}
+
+func GetDnsData(netappClient *netapp.Client) (r []*Dns, err error) {
+
+	var l struct {
+		NetDnsInfo []netDnsInfo `xml:"net-dns-info"`
+	}
+	if err = zapiGetIter(netappClient, "net-dns-get-iter", nil, &l); err != nil {
+		return
+	}
+
+	for _, n := range l.NetDnsInfo {
+		r = append(r, &Dns{
+			Vserver:     n.VserverName,
+			State:       n.DnsState,
+			Domains:     n.Domains,
+			NameServers: n.NameServers,
+		})
+	}
+	return
+}
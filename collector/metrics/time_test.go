@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+const timeNodeReply = `<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'><results status="passed"><attributes-list>
+<node-details-info><node>node-01</node></node-details-info>
+<node-details-info><node>node-02</node></node-details-info>
+</attributes-list><num-records>2</num-records></results></netapp>`
+
+func TestGetNodeClockData(t *testing.T) {
+	netappClient, stop := newZapiTestClient(map[string]string{
+		"system-node-get-iter": timeNodeReply,
+		"clock-get-clock":      fmt.Sprintf(`<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'><results status="passed"><utc-time>%d</utc-time></results></netapp>`, time.Now().Unix()+100),
+	})
+	defer stop()
+
+	clocks, failed := GetNodeClockData(netappClient)
+	if len(clocks) != 2 || len(failed) != 0 {
+		t.Fatalf("got clocks %v and failed nodes %v, expected the clocks of both nodes", clocks, failed)
+	}
+	for _, clock := range clocks {
+		// the clock of the node has a resolution of one second.
+		if math.Abs(clock.Offset-100) > 2 {
+			t.Errorf("got offset %v of %s, expected about 100", clock.Offset, clock.Node)
+		}
+	}
+}
+
+// TestGetNodeClockDataFailure checks the nodes whose clock can't be read are
+// reported as failed.
+func TestGetNodeClockDataFailure(t *testing.T) {
+	netappClient, stop := newZapiTestClient(map[string]string{"system-node-get-iter": timeNodeReply})
+	defer stop()
+
+	clocks, failed := GetNodeClockData(netappClient)
+	if len(clocks) != 0 || len(failed) != 2 {
+		t.Errorf("got clocks %v and failed nodes %v, expected both nodes failed", clocks, failed)
+	}
+}
@@ -1,9 +1,11 @@
 package utils
 
 import (
-	"github.com/prometheus/client_golang/prometheus"
 	"bytes"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
 	"strconv"
+	"strings"
 )
 
 const (
@@ -99,3 +101,37 @@ func BoolToFloat64 (data bool) float64 {
 		return float64(0)
 	}
 }
+
+// ZapiVersion is an ONTAPI version such as 1.130, it can't be compared as a float.
+type ZapiVersion struct {
+	Major int
+	Minor int
+}
+
+func ParseZapiVersion(data string) (ZapiVersion, bool) {
+	parts := strings.SplitN(data, ".", 2)
+	if len(parts) != 2 {
+		return ZapiVersion{}, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return ZapiVersion{}, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return ZapiVersion{}, false
+	}
+	return ZapiVersion{Major: major, Minor: minor}, true
+}
+
+func (v ZapiVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// AtLeast reports whether v is the same as or newer than min.
+func (v ZapiVersion) AtLeast(min ZapiVersion) bool {
+	if v.Major != min.Major {
+		return v.Major > min.Major
+	}
+	return v.Minor >= min.Minor
+}
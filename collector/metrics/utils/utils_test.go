@@ -0,0 +1,44 @@
+package utils
+
+import "testing"
+
+func TestParseZapiVersion(t *testing.T) {
+	tests := []struct {
+		data    string
+		version ZapiVersion
+		ok      bool
+	}{
+		{"1.130", ZapiVersion{Major: 1, Minor: 130}, true},
+		{"1.20", ZapiVersion{Major: 1, Minor: 20}, true},
+		{"1.9", ZapiVersion{Major: 1, Minor: 9}, true},
+		{"1", ZapiVersion{}, false},
+		{"", ZapiVersion{}, false},
+		{"1.x", ZapiVersion{}, false},
+		{"a.130", ZapiVersion{}, false},
+		{"1.130.1", ZapiVersion{}, false},
+	}
+	for _, test := range tests {
+		version, ok := ParseZapiVersion(test.data)
+		if version != test.version || ok != test.ok {
+			t.Errorf("ParseZapiVersion(%q) = %v, %v; want %v, %v", test.data, version, ok, test.version, test.ok)
+		}
+	}
+}
+
+func TestZapiVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		v, min ZapiVersion
+		want   bool
+	}{
+		{ZapiVersion{1, 130}, ZapiVersion{1, 20}, true},
+		{ZapiVersion{1, 20}, ZapiVersion{1, 130}, false},
+		{ZapiVersion{1, 100}, ZapiVersion{1, 100}, true},
+		{ZapiVersion{2, 0}, ZapiVersion{1, 200}, true},
+		{ZapiVersion{1, 200}, ZapiVersion{2, 0}, false},
+	}
+	for _, test := range tests {
+		if got := test.v.AtLeast(test.min); got != test.want {
+			t.Errorf("%v.AtLeast(%v) = %v; want %v", test.v, test.min, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"encoding/xml"
+	"regexp"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/pepabo/go-netapp/netapp"
+)
+
+var releasePattern = regexp.MustCompile(`NetApp Release ([^:\s]+)`)
+
+type SystemVersion struct {
+	// Version is the full version string, e.g. "NetApp Release 9.3P2: Thu Feb 08 ..."
+	Version    string
+	Release    string
+	Generation int
+	Major      int
+	Minor      int
+}
+
+type systemGetVersionResponse struct {
+	XMLName xml.Name `xml:"netapp"`
+	Results struct {
+		Version      string `xml:"version"`
+		VersionTuple struct {
+			Generation int `xml:"generation"`
+			Major      int `xml:"major"`
+			Minor      int `xml:"minor"`
+		} `xml:"version-tuple>system-version-tuple"`
+	} `xml:"results"`
+}
+
+// GetSystemVersion returns the ONTAP release of the cluster via system-get-version.
+func GetSystemVersion(netappClient *netapp.Client) (*SystemVersion, error) {
+	api := struct {
+		XMLName xml.Name `xml:"system-get-version"`
+	}{}
+	var res systemGetVersionResponse
+	if err := zapiInvoke(netappClient, &api, &res); err != nil {
+		return nil, err
+	}
+
+	v := &SystemVersion{
+		Version:    res.Results.Version,
+		Generation: res.Results.VersionTuple.Generation,
+		Major:      res.Results.VersionTuple.Major,
+		Minor:      res.Results.VersionTuple.Minor,
+		Release:    parseRelease(res.Results.Version),
+	}
+	return v, nil
+}
+
+// parseRelease extracts the release, e.g. "9.3P2", from an ONTAP version string,
+// the version string is returned unchanged if it does not match.
+func parseRelease(version string) string {
+	if m := releasePattern.FindStringSubmatch(version); m != nil {
+		return m[1]
+	}
+	return version
+}
+
+type systemGetOntapiVersionResponse struct {
+	XMLName xml.Name `xml:"netapp"`
+	Results struct {
+		MajorVersion int `xml:"major-version"`
+		MinorVersion int `xml:"minor-version"`
+	} `xml:"results"`
+}
+
+// GetOntapiVersion returns the highest ZAPI version supported by the cluster
+// via system-get-ontapi-version.
+func GetOntapiVersion(netappClient *netapp.Client) (utils.ZapiVersion, error) {
+	api := struct {
+		XMLName xml.Name `xml:"system-get-ontapi-version"`
+	}{}
+	var res systemGetOntapiVersionResponse
+	if err := zapiInvoke(netappClient, &api, &res); err != nil {
+		return utils.ZapiVersion{}, err
+	}
+	return utils.ZapiVersion{Major: res.Results.MajorVersion, Minor: res.Results.MinorVersion}, nil
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"log"
+	"regexp"
 
 	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
 	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
@@ -16,7 +17,7 @@ const (
 
 // Metric descriptors.
 var (
-	volumeLabels   = append(variables.BaseLabelNames, "volume", "vserver", "aggr", "node")
+	volumeLabels   = append(variables.BaseLabelNames, "volume", "vserver", "aggr", "node", "flexgroup")
 	VolumeSizeDesc = prometheus.NewDesc(
 		prometheus.BuildFQName(variables.Namespace, VolumeSubsystem, "size"),
 		"Size of the volume.",
@@ -45,8 +46,33 @@ var (
 		prometheus.BuildFQName(variables.Namespace, VolumeSubsystem, "state"),
 		"State of the volume, 1 (online), 0(offline), 2(restricted), or 3(mixed).",
 		volumeLabels, nil)
+	VolumeInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, VolumeSubsystem, "info"),
+		"Information of the volume, style is flexvol, flexgroup or flexgroup_constituent.",
+		append(volumeLabels, "style"), nil)
+	volumeFlexgroupLabels           = append(variables.BaseLabelNames, "volume", "vserver")
+	VolumeFlexgroupConstituentsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, VolumeSubsystem, "flexgroup_constituents"),
+		"Number of constituents of the FlexGroup.",
+		volumeFlexgroupLabels, nil)
+	VolumeFlexgroupSizeTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, VolumeSubsystem, "flexgroup_size_total"),
+		"Total Size of the FlexGroup, as reported by the FlexGroup itself.",
+		volumeFlexgroupLabels, nil)
+	VolumeFlexgroupSizeUsedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, VolumeSubsystem, "flexgroup_size_used"),
+		"Used Size of the FlexGroup, as reported by the FlexGroup itself.",
+		volumeFlexgroupLabels, nil)
+	VolumeFlexgroupImbalanceDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, VolumeSubsystem, "flexgroup_imbalance_percent"),
+		"Used percentage of the fullest constituent of the FlexGroup minus the average used percentage of its constituents.",
+		volumeFlexgroupLabels, nil)
 )
 
+// flexgroupConstituentRegexp matches the suffix ONTAP appends to the name of
+// the FlexGroup for its constituents, e.g. fg__0001.
+var flexgroupConstituentRegexp = regexp.MustCompile(`__[0-9]+$`)
+
 // Scrapesystem collects system Volume info
 type ScrapeVolume struct{}
 
@@ -60,6 +86,11 @@ func (ScrapeVolume) Help() string {
 	return "Collect Netapp Volume info;"
 }
 
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeVolume) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 20}
+}
+
 type Volume struct {
 	Name                   string
 	Vserver                string
@@ -72,13 +103,31 @@ type Volume struct {
 	SizeUsedBySnapshots    string
 	SizeReservedBySnapshot string
 	State                  string
+	// Style is flexvol, flexgroup or flexgroup_constituent.
+	Style string
+	// FlexGroup is the name of the FlexGroup for the FlexGroup itself and its
+	// constituents, empty for FlexVols.
+	FlexGroup string
+}
+
+// flexgroupUsage is the usage of a FlexGroup and of its constituents.
+type flexgroupUsage struct {
+	constituents int
+	// hasSize is set once the FlexGroup itself reported its sizes, which
+	// already cover its constituents.
+	hasSize      bool
+	sizeTotal    float64
+	sizeUsed     float64
+	usedPercents []float64
 }
 
 // Scrape collects data from  netapp system and Volume info
 func (ScrapeVolume) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
 
+	flexgroups := make(map[[2]string]*flexgroupUsage)
 	for _, VolumeInfo := range GetVolumeData(netappClient) {
-		vserverLabelValues := append(variables.BaseLabelValues, VolumeInfo.Name, VolumeInfo.Vserver, VolumeInfo.Aggr, VolumeInfo.Node)
+		vserverLabelValues := append(variables.BaseLabelValues, VolumeInfo.Name, VolumeInfo.Vserver, VolumeInfo.Aggr, VolumeInfo.Node, VolumeInfo.FlexGroup)
+		ch <- prometheus.MustNewConstMetric(VolumeInfoDesc, prometheus.GaugeValue, 1, append(vserverLabelValues, VolumeInfo.Style)...)
 		ch <- prometheus.MustNewConstMetric(VolumeSizeDesc, prometheus.GaugeValue, float64(VolumeInfo.Size), vserverLabelValues...)
 		if sizeAvailable, ok := utils.ParseStatus(VolumeInfo.SizeAvailable); ok {
 			ch <- prometheus.MustNewConstMetric(VolumeSizeAvailableDesc, prometheus.GaugeValue, sizeAvailable, vserverLabelValues...)
@@ -99,6 +148,49 @@ func (ScrapeVolume) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Met
 			ch <- prometheus.MustNewConstMetric(VolumeStateDesc, prometheus.GaugeValue, stateVal, vserverLabelValues...)
 		}
 
+		if VolumeInfo.FlexGroup == "" {
+			continue
+		}
+		key := [2]string{VolumeInfo.Vserver, VolumeInfo.FlexGroup}
+		usage, ok := flexgroups[key]
+		if !ok {
+			usage = &flexgroupUsage{}
+			flexgroups[key] = usage
+		}
+		sizeTotal, totalOk := utils.ParseStatus(VolumeInfo.SizeTotal)
+		sizeUsed, usedOk := utils.ParseStatus(VolumeInfo.SizeUsed)
+		switch VolumeInfo.Style {
+		case "flexgroup":
+			if totalOk && usedOk {
+				usage.hasSize = true
+				usage.sizeTotal = sizeTotal
+				usage.sizeUsed = sizeUsed
+			}
+		case "flexgroup_constituent":
+			usage.constituents++
+			if totalOk && usedOk && sizeTotal > 0 {
+				usage.usedPercents = append(usage.usedPercents, sizeUsed/sizeTotal*100)
+			}
+		}
+	}
+
+	for key, usage := range flexgroups {
+		flexgroupLabelValues := append(variables.BaseLabelValues, key[1], key[0])
+		ch <- prometheus.MustNewConstMetric(VolumeFlexgroupConstituentsDesc, prometheus.GaugeValue, float64(usage.constituents), flexgroupLabelValues...)
+		if usage.hasSize {
+			ch <- prometheus.MustNewConstMetric(VolumeFlexgroupSizeTotalDesc, prometheus.GaugeValue, usage.sizeTotal, flexgroupLabelValues...)
+			ch <- prometheus.MustNewConstMetric(VolumeFlexgroupSizeUsedDesc, prometheus.GaugeValue, usage.sizeUsed, flexgroupLabelValues...)
+		}
+		if len(usage.usedPercents) > 0 {
+			var max, sum float64
+			for _, usedPercent := range usage.usedPercents {
+				sum += usedPercent
+				if usedPercent > max {
+					max = usedPercent
+				}
+			}
+			ch <- prometheus.MustNewConstMetric(VolumeFlexgroupImbalanceDesc, prometheus.GaugeValue, max-sum/float64(len(usage.usedPercents)), flexgroupLabelValues...)
+		}
 	}
 	return nil
 }
@@ -118,6 +210,8 @@ func GetVolumeData(netappClient *netapp.Client) (r []*Volume) {
 					OwningVserverName:       "x",
 					ContainingAggregateName: "x",
 					Node:                    "x",
+					Style:                   "x",
+					StyleExtended:           "x",
 				},
 				VolumeSpaceAttributes: &netapp.VolumeSpaceAttributes{
 					Size:                1,
@@ -137,6 +231,23 @@ func GetVolumeData(netappClient *netapp.Client) (r []*Volume) {
 	l := getVolumeList(netappClient, opts)
 
 	for _, n := range l {
+		// only style-extended tells constituents apart from their FlexGroup,
+		// fall back to style when the filer does not report it, which calls
+		// a FlexVol flex.
+		style := n.VolumeIDAttributes.StyleExtended
+		if style == "" {
+			style = n.VolumeIDAttributes.Style
+		}
+		if style == "flex" {
+			style = "flexvol"
+		}
+		flexgroup := ""
+		switch style {
+		case "flexgroup":
+			flexgroup = n.VolumeIDAttributes.Name
+		case "flexgroup_constituent":
+			flexgroup = flexgroupConstituentRegexp.ReplaceAllString(n.VolumeIDAttributes.Name, "")
+		}
 		r = append(r, &Volume{
 			Name:                   n.VolumeIDAttributes.Name,
 			Vserver:                n.VolumeIDAttributes.OwningVserverName,
@@ -149,6 +260,8 @@ func GetVolumeData(netappClient *netapp.Client) (r []*Volume) {
 			SizeUsedBySnapshots:    n.VolumeSpaceAttributes.SizeUsedBySnapshots,
 			SizeReservedBySnapshot: n.VolumeSpaceAttributes.SnapshotReserveSize,
 			State:                  n.VolumeStateAttributes.State,
+			Style:                  style,
+			FlexGroup:              flexgroup,
 		})
 	}
 	return
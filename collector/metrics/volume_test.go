@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const volumeGetIterReply = `<?xml version='1.0' encoding='UTF-8' ?>
+<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'>
+<results status="passed">
+<attributes-list>
+<volume-attributes><volume-id-attributes><name>fg</name><owning-vserver-name>svm1</owning-vserver-name><style>flexgroup</style><style-extended>flexgroup</style-extended></volume-id-attributes><volume-space-attributes><size-total>3000</size-total><size-used>1000</size-used></volume-space-attributes><volume-state-attributes><state>online</state></volume-state-attributes></volume-attributes>
+<volume-attributes><volume-id-attributes><name>fg__0001</name><owning-vserver-name>svm1</owning-vserver-name><style>flexgroup</style><style-extended>flexgroup_constituent</style-extended></volume-id-attributes><volume-space-attributes><size-total>1500</size-total><size-used>300</size-used></volume-space-attributes><volume-state-attributes><state>online</state></volume-state-attributes></volume-attributes>
+<volume-attributes><volume-id-attributes><name>fg__0002</name><owning-vserver-name>svm1</owning-vserver-name><style>flexgroup</style><style-extended>flexgroup_constituent</style-extended></volume-id-attributes><volume-space-attributes><size-total>1500</size-total><size-used>700</size-used></volume-space-attributes><volume-state-attributes><state>online</state></volume-state-attributes></volume-attributes>
+<volume-attributes><volume-id-attributes><name>vol1</name><owning-vserver-name>svm1</owning-vserver-name><style>flex</style></volume-id-attributes><volume-space-attributes><size-total>100</size-total><size-used>10</size-used></volume-space-attributes><volume-state-attributes><state>online</state></volume-state-attributes></volume-attributes>
+</attributes-list>
+<num-records>4</num-records>
+</results>
+</netapp>`
+
+// TestScrapeVolumeFlexgroup checks the sizes of a FlexGroup are its own, not
+// added up with its constituents, and that the style of a FlexVol reported
+// without style-extended is flexvol.
+func TestScrapeVolumeFlexgroup(t *testing.T) {
+	netappClient, stop := newZapiTestClient(map[string]string{"volume-get-iter": volumeGetIterReply})
+	defer stop()
+
+	expected := `
+# HELP netapp_volume_flexgroup_constituents Number of constituents of the FlexGroup.
+# TYPE netapp_volume_flexgroup_constituents gauge
+netapp_volume_flexgroup_constituents{cluster="",group="",volume="fg",vserver="svm1"} 2
+# HELP netapp_volume_flexgroup_imbalance_percent Used percentage of the fullest constituent of the FlexGroup minus the average used percentage of its constituents.
+# TYPE netapp_volume_flexgroup_imbalance_percent gauge
+netapp_volume_flexgroup_imbalance_percent{cluster="",group="",volume="fg",vserver="svm1"} 13.333333333333336
+# HELP netapp_volume_flexgroup_size_total Total Size of the FlexGroup, as reported by the FlexGroup itself.
+# TYPE netapp_volume_flexgroup_size_total gauge
+netapp_volume_flexgroup_size_total{cluster="",group="",volume="fg",vserver="svm1"} 3000
+# HELP netapp_volume_flexgroup_size_used Used Size of the FlexGroup, as reported by the FlexGroup itself.
+# TYPE netapp_volume_flexgroup_size_used gauge
+netapp_volume_flexgroup_size_used{cluster="",group="",volume="fg",vserver="svm1"} 1000
+# HELP netapp_volume_info Information of the volume, style is flexvol, flexgroup or flexgroup_constituent.
+# TYPE netapp_volume_info gauge
+netapp_volume_info{aggr="",cluster="",flexgroup="",group="",node="",style="flexvol",volume="vol1",vserver="svm1"} 1
+netapp_volume_info{aggr="",cluster="",flexgroup="fg",group="",node="",style="flexgroup",volume="fg",vserver="svm1"} 1
+netapp_volume_info{aggr="",cluster="",flexgroup="fg",group="",node="",style="flexgroup_constituent",volume="fg__0001",vserver="svm1"} 1
+netapp_volume_info{aggr="",cluster="",flexgroup="fg",group="",node="",style="flexgroup_constituent",volume="fg__0002",vserver="svm1"} 1
+`
+	c := scraperCollector{t: t, scraper: ScrapeVolume{}, netappClient: netappClient}
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected), "netapp_volume_flexgroup_constituents", "netapp_volume_flexgroup_imbalance_percent", "netapp_volume_flexgroup_size_total", "netapp_volume_flexgroup_size_used", "netapp_volume_info"); err != nil {
+		t.Error(err)
+	}
+}
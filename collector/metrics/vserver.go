@@ -1,7 +1,8 @@
 package metrics
 
 import (
-	"log"
+	"encoding/xml"
+	"strings"
 
 	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
 	"github.com/jenningsloy318/netapp_exporter/collector/metrics/variables"
@@ -29,6 +30,22 @@ var (
 		prometheus.BuildFQName(variables.Namespace, VserverSubsystem, "operational_state"),
 		"Operational State of the vserver, 1(running), 0(stopped).",
 		vserverLabels, nil)
+	VServerInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, VserverSubsystem, "info"),
+		"Information of the vserver, allowed_protocols and disallowed_protocols are comma separated.",
+		append(vserverLabels, "allowed_protocols", "disallowed_protocols", "root_volume", "root_volume_aggregate", "language", "snapshot_policy", "qos_policy"), nil)
+	VServerProtocolAllowedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, VserverSubsystem, "protocol_allowed"),
+		"whether the protocol is allowed on the vserver.",
+		append(vserverLabels, "protocol"), nil)
+	VServerMaxVolumesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, VserverSubsystem, "max_volumes"),
+		"Maximum number of volumes of the vserver, not exported when unlimited.",
+		vserverLabels, nil)
+	VServerVolumesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(variables.Namespace, VserverSubsystem, "volumes"),
+		"Number of volumes of the vserver counted against max_volumes, without its root volume and the constituents of its FlexGroups.",
+		vserverLabels, nil)
 )
 
 // Scrapesystem collects system vserver info
@@ -44,18 +61,75 @@ func (ScrapeVserver) Help() string {
 	return "Collect Netapp Vserver info;"
 }
 
+// Version of ZAPI from which the Scraper is available.
+func (ScrapeVserver) Version() utils.ZapiVersion {
+	return utils.ZapiVersion{Major: 1, Minor: 20}
+}
+
 type VServer struct {
 	VserverName                string
 	VserverType                string
 	VolumeDeleteRetentionHours int
 	State                      string
 	OperationalState           string
+	AllowedProtocols           []string
+	DisallowedProtocols        []string
+	RootVolume                 string
+	RootVolumeAggregate        string
+	MaxVolumes                 string
+	Language                   string
+	SnapshotPolicy             string
+	QosPolicyGroup             string
+}
+
+// vserverInfo is both the desired attributes and the record of vserver-get-iter,
+// go-netapp misses the QoS policy group of it.
+type vserverInfo struct {
+	XMLName                    xml.Name `xml:"vserver-info"`
+	VserverName                string   `xml:"vserver-name"`
+	VserverType                string   `xml:"vserver-type"`
+	VolumeDeleteRetentionHours int      `xml:"volume-delete-retention-hours"`
+	State                      string   `xml:"state"`
+	OperationalState           string   `xml:"operational-state"`
+	AllowedProtocols           struct {
+		Protocol []string `xml:"protocol"`
+	} `xml:"allowed-protocols"`
+	DisallowedProtocols struct {
+		Protocol []string `xml:"protocol"`
+	} `xml:"disallowed-protocols"`
+	RootVolume          string `xml:"root-volume"`
+	RootVolumeAggregate string `xml:"root-volume-aggregate"`
+	MaxVolumes          string `xml:"max-volumes"`
+	Language            string `xml:"language"`
+	SnapshotPolicy      string `xml:"snapshot-policy"`
+	QosPolicyGroup      string `xml:"qos-policy-group"`
+}
+
+// volumeVserverInfo is both the desired attributes and the record of
+// volume-get-iter to count the volumes of the vservers.
+type volumeVserverInfo struct {
+	XMLName            xml.Name `xml:"volume-attributes"`
+	VolumeIDAttributes struct {
+		OwningVserverName string `xml:"owning-vserver-name"`
+		StyleExtended     string `xml:"style-extended"`
+	} `xml:"volume-id-attributes"`
+	VolumeStateAttributes struct {
+		IsVserverRoot bool `xml:"is-vserver-root"`
+	} `xml:"volume-state-attributes"`
 }
 
 // Scrape collects data from  netapp system and vserver info
 func (ScrapeVserver) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error {
 
-	for _, VserverInfo := range GetVserverData(netappClient) {
+	vservers, err := GetVserverData(netappClient)
+	if err != nil {
+		return err
+	}
+	volumeCounts, err := GetVserverVolumeCount(netappClient)
+	if err != nil {
+		return err
+	}
+	for _, VserverInfo := range vservers {
 		vserverLabelValues := append(variables.BaseLabelValues, VserverInfo.VserverName, VserverInfo.VserverType)
 		ch <- prometheus.MustNewConstMetric(VServerVolumeDeleteRetentionHoursDesc, prometheus.GaugeValue, float64(VserverInfo.VolumeDeleteRetentionHours), vserverLabelValues...)
 		if len(VserverInfo.State) > 0 {
@@ -68,36 +142,68 @@ func (ScrapeVserver) Scrape(netappClient *netapp.Client, ch chan<- prometheus.Me
 				ch <- prometheus.MustNewConstMetric(VServerOperationalStateDesc, prometheus.GaugeValue, opsStateVal, vserverLabelValues...)
 			}
 		}
+		ch <- prometheus.MustNewConstMetric(VServerInfoDesc, prometheus.GaugeValue, 1, append(vserverLabelValues, strings.Join(VserverInfo.AllowedProtocols, ","), strings.Join(VserverInfo.DisallowedProtocols, ","), VserverInfo.RootVolume, VserverInfo.RootVolumeAggregate, VserverInfo.Language, VserverInfo.SnapshotPolicy, VserverInfo.QosPolicyGroup)...)
+		for _, protocol := range VserverInfo.AllowedProtocols {
+			ch <- prometheus.MustNewConstMetric(VServerProtocolAllowedDesc, prometheus.GaugeValue, 1, append(vserverLabelValues, protocol)...)
+		}
+		for _, protocol := range VserverInfo.DisallowedProtocols {
+			ch <- prometheus.MustNewConstMetric(VServerProtocolAllowedDesc, prometheus.GaugeValue, 0, append(vserverLabelValues, protocol)...)
+		}
+		if maxVolumes, ok := utils.ParseStatus(VserverInfo.MaxVolumes); ok {
+			ch <- prometheus.MustNewConstMetric(VServerMaxVolumesDesc, prometheus.GaugeValue, maxVolumes, vserverLabelValues...)
+		}
+		ch <- prometheus.MustNewConstMetric(VServerVolumesDesc, prometheus.GaugeValue, float64(volumeCounts[VserverInfo.VserverName]), vserverLabelValues...)
 
 	}
 	return nil
 }
 
-func GetVserverData(netappClient *netapp.Client) (r []*VServer) {
-	opts := &netapp.VServerOptions{
-		Query: &netapp.VServerQuery{},
-		DesiredAttributes: &netapp.VServerQuery{
-			VServerInfo: &netapp.VServerInfo{
-				VserverName:                "x",
-				VserverType:                "x",
-				VolumeDeleteRetentionHours: 1,
-				State:                      "x",
-				OperationalState:           "x",
-			},
-		},
+func GetVserverData(netappClient *netapp.Client) (r []*VServer, err error) {
+
+	var l struct {
+		VserverInfo []vserverInfo `xml:"vserver-info"`
 	}
-	l, _, err := netappClient.VServer.List(opts)
-	if err !=nil  {
-		log.Fatalf("error when getting VServers, %s",err)
+	if err = zapiGetIterAttributes(netappClient, "vserver-get-iter", nil, &vserverInfo{}, &l); err != nil {
+		return
 	}
-	for _, n := range l.Results.AttributesList.VserverInfo {
+
+	for _, n := range l.VserverInfo {
 		r = append(r, &VServer{
 			VserverName:                n.VserverName,
 			VserverType:                n.VserverType,
 			VolumeDeleteRetentionHours: n.VolumeDeleteRetentionHours,
 			State:                      n.State,
 			OperationalState:           n.OperationalState,
+			AllowedProtocols:           n.AllowedProtocols.Protocol,
+			DisallowedProtocols:        n.DisallowedProtocols.Protocol,
+			RootVolume:                 n.RootVolume,
+			RootVolumeAggregate:        n.RootVolumeAggregate,
+			MaxVolumes:                 n.MaxVolumes,
+			Language:                   n.Language,
+			SnapshotPolicy:             n.SnapshotPolicy,
+			QosPolicyGroup:             n.QosPolicyGroup,
 		})
 	}
 	return
 }
+
+// GetVserverVolumeCount returns the number of volumes of each vserver, the root
+// volume and the constituents of the FlexGroups are not counted.
+func GetVserverVolumeCount(netappClient *netapp.Client) (r map[string]int, err error) {
+
+	var l struct {
+		VolumeAttributes []volumeVserverInfo `xml:"volume-attributes"`
+	}
+	if err = zapiGetIterAttributes(netappClient, "volume-get-iter", nil, &volumeVserverInfo{}, &l); err != nil {
+		return
+	}
+
+	r = make(map[string]int)
+	for _, n := range l.VolumeAttributes {
+		if n.VolumeStateAttributes.IsVserverRoot || n.VolumeIDAttributes.StyleExtended == "flexgroup_constituent" {
+			continue
+		}
+		r[n.VolumeIDAttributes.OwningVserverName]++
+	}
+	return
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"reflect"
+	"testing"
+)
+
+const vserverVolumeGetIterReply = `<?xml version='1.0' encoding='UTF-8' ?>
+<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'>
+<results status="passed">
+<attributes-list>
+<volume-attributes><volume-id-attributes><owning-vserver-name>svm1</owning-vserver-name><style-extended>flexvol</style-extended></volume-id-attributes><volume-state-attributes><is-vserver-root>true</is-vserver-root></volume-state-attributes></volume-attributes>
+<volume-attributes><volume-id-attributes><owning-vserver-name>svm1</owning-vserver-name><style-extended>flexvol</style-extended></volume-id-attributes><volume-state-attributes><is-vserver-root>false</is-vserver-root></volume-state-attributes></volume-attributes>
+<volume-attributes><volume-id-attributes><owning-vserver-name>svm1</owning-vserver-name><style-extended>flexgroup</style-extended></volume-id-attributes><volume-state-attributes><is-vserver-root>false</is-vserver-root></volume-state-attributes></volume-attributes>
+<volume-attributes><volume-id-attributes><owning-vserver-name>svm1</owning-vserver-name><style-extended>flexgroup_constituent</style-extended></volume-id-attributes><volume-state-attributes><is-vserver-root>false</is-vserver-root></volume-state-attributes></volume-attributes>
+<volume-attributes><volume-id-attributes><owning-vserver-name>svm1</owning-vserver-name><style-extended>flexgroup_constituent</style-extended></volume-id-attributes><volume-state-attributes><is-vserver-root>false</is-vserver-root></volume-state-attributes></volume-attributes>
+<volume-attributes><volume-id-attributes><owning-vserver-name>svm2</owning-vserver-name><style-extended>flexvol</style-extended></volume-id-attributes><volume-state-attributes><is-vserver-root>true</is-vserver-root></volume-state-attributes></volume-attributes>
+</attributes-list>
+<num-records>6</num-records>
+</results>
+</netapp>`
+
+// TestGetVserverVolumeCount checks the root volume and the FlexGroup
+// constituents are not counted against the volume limit.
+func TestGetVserverVolumeCount(t *testing.T) {
+	netappClient, stop := newZapiTestClient(map[string]string{"volume-get-iter": vserverVolumeGetIterReply})
+	defer stop()
+
+	counts, err := GetVserverVolumeCount(netappClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]int{"svm1": 2}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("got %v, expected %v", counts, expected)
+	}
+}
@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/pepabo/go-netapp/netapp"
+)
+
+// zapiMaxRecords is the page size requested from *-get-iter APIs.
+const zapiMaxRecords = 500
+
+// zapiRequest is the envelope of a ZAPI call which go-netapp does not wrap.
+// Api must be a struct whose XMLName is the name of the API, NodeName tunnels
+// a node API to the node and Vfiler a vserver API to the vserver.
+type zapiRequest struct {
+	XMLName  xml.Name `xml:"netapp"`
+	Version  string   `xml:"version,attr"`
+	XMLNs    string   `xml:"xmlns,attr"`
+	NodeName string   `xml:"nodename,attr,omitempty"`
+	Vfiler   string   `xml:"vfiler,attr,omitempty"`
+	Api      interface{}
+}
+
+type zapiStatus struct {
+	XMLName xml.Name                `xml:"netapp"`
+	Results netapp.SingleResultBase `xml:"results"`
+}
+
+// zapiElement holds a typed element such as the record of a query; the
+// element name is taken from the XMLName of Value.
+type zapiElement struct {
+	Value interface{}
+}
+
+type zapiIterRequest struct {
+	XMLName           xml.Name
+	DesiredAttributes *zapiElement `xml:"desired-attributes,omitempty"`
+	MaxRecords        int          `xml:"max-records,omitempty"`
+	Query             *zapiElement `xml:"query,omitempty"`
+	Tag               string       `xml:"tag,omitempty"`
+}
+
+type zapiIterResponse struct {
+	XMLName xml.Name `xml:"netapp"`
+	Results struct {
+		AttributesList struct {
+			Records []byte `xml:",innerxml"`
+		} `xml:"attributes-list"`
+		NextTag string `xml:"next-tag"`
+	} `xml:"results"`
+}
+
+// zapiInvoke sends api to the filer and decodes the reply into response.
+// A reply with a failed status is returned as an error.
+func zapiInvoke(netappClient *netapp.Client, api interface{}, response interface{}) error {
+	return zapiInvokeRequest(netappClient, &zapiRequest{Api: api}, response)
+}
+
+// zapiInvokeNode is zapiInvoke for the node APIs, which are run on node.
+func zapiInvokeNode(netappClient *netapp.Client, node string, api interface{}, response interface{}) error {
+	return zapiInvokeRequest(netappClient, &zapiRequest{NodeName: node, Api: api}, response)
+}
+
+// zapiInvokeVserver is zapiInvoke for the vserver APIs, which are run on vserver.
+func zapiInvokeVserver(netappClient *netapp.Client, vserver string, api interface{}, response interface{}) error {
+	return zapiInvokeRequest(netappClient, &zapiRequest{Vfiler: vserver, Api: api}, response)
+}
+
+func zapiInvokeRequest(netappClient *netapp.Client, request *zapiRequest, response interface{}) error {
+	request.Version = netappClient.System.Version
+	request.XMLNs = netapp.XMLNs
+	req, err := netappClient.NewRequest("POST", request)
+	if err != nil {
+		return err
+	}
+	res, err := netappClient.Do(req, nil)
+	if err != nil {
+		return err
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	var status zapiStatus
+	if err := xml.Unmarshal(body, &status); err != nil {
+		return err
+	}
+	if !status.Results.Passed() {
+		return fmt.Errorf("%s (errno %d)", status.Results.Reason, status.Results.ErrorNo)
+	}
+	return xml.Unmarshal(body, response)
+}
+
+// zapiGetIter walks all pages of the iterator api and decodes the records of
+// every page into list, which is a pointer to a struct holding a slice tagged
+// with the record element name. query is optional.
+func zapiGetIter(netappClient *netapp.Client, api string, query interface{}, list interface{}) error {
+	return zapiGetIterAttributes(netappClient, api, query, nil, list)
+}
+
+// zapiGetIterAttributes is zapiGetIter returning only the desired attributes,
+// desired is a zero record whose empty elements name the attributes.
+func zapiGetIterAttributes(netappClient *netapp.Client, api string, query interface{}, desired interface{}, list interface{}) error {
+	request := &zapiIterRequest{
+		XMLName:    xml.Name{Local: api},
+		MaxRecords: zapiMaxRecords,
+	}
+	if query != nil {
+		request.Query = &zapiElement{Value: query}
+	}
+	if desired != nil {
+		request.DesiredAttributes = &zapiElement{Value: desired}
+	}
+
+	for {
+		var res zapiIterResponse
+		if err := zapiInvoke(netappClient, request, &res); err != nil {
+			return fmt.Errorf("%s: %s", api, err)
+		}
+		records := append([]byte("<attributes-list>"), res.Results.AttributesList.Records...)
+		records = append(records, []byte("</attributes-list>")...)
+		if err := xml.Unmarshal(records, list); err != nil {
+			return fmt.Errorf("%s: %s", api, err)
+		}
+		if res.Results.NextTag == "" {
+			return nil
+		}
+		request.Tag = res.Results.NextTag
+	}
+}
+
+// zapiSupports reports whether the client talks at least ZAPI version min, it is
+// used for the optional APIs of a scraper.
+func zapiSupports(netappClient *netapp.Client, min utils.ZapiVersion) bool {
+	version, ok := utils.ParseZapiVersion(netappClient.System.Version)
+	return ok && version.AtLeast(min)
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// zapiTestFailure is the reply of the test filer to an API it has no reply for.
+const zapiTestFailure = `<netapp version='1.170' xmlns='http://www.netapp.com/filer/admin'><results status="failed" errno="13005" reason="Unable to find API"/></netapp>`
+
+// newZapiTestClient returns a client of a filer answering each API with its
+// reply in replies, the filer is stopped by the returned func.
+func newZapiTestClient(replies map[string]string) (*netapp.Client, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Api struct {
+				XMLName xml.Name
+			} `xml:",any"`
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		xml.Unmarshal(body, &request)
+		if reply, ok := replies[request.Api.XMLName.Local]; ok {
+			fmt.Fprint(w, reply)
+			return
+		}
+		fmt.Fprint(w, zapiTestFailure)
+	}))
+	netappClient := netapp.NewClient(srv.URL, "1.170", &netapp.ClientOptions{Timeout: 5 * time.Second})
+	return netappClient, srv.Close
+}
+
+// scraperCollector collects the metrics of a Scraper, for comparing them with testutil.
+type scraperCollector struct {
+	t       *testing.T
+	scraper interface {
+		Scrape(*netapp.Client, chan<- prometheus.Metric) error
+	}
+	netappClient *netapp.Client
+}
+
+func (c scraperCollector) Describe(ch chan<- *prometheus.Desc) {}
+
+func (c scraperCollector) Collect(ch chan<- prometheus.Metric) {
+	if err := c.scraper.Scrape(c.netappClient, ch); err != nil {
+		c.t.Errorf("scrape: %s", err)
+	}
+}
@@ -1,9 +1,9 @@
 package collector
 
 import (
-
-	"github.com/prometheus/client_golang/prometheus"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
 	"github.com/pepabo/go-netapp/netapp"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Scraper is minimal interface that let's you add new prometheus metrics to netapp_exporter.
@@ -13,6 +13,9 @@ type Scraper interface {
 	// Help describes the role of the Scraper.
 	// Example: "Collect  node metrics"
 	Help() string
+	// Version of ZAPI from which the Scraper is available.
+	// Example: utils.ZapiVersion{Major: 1, Minor: 130} for ONTAP 9.3
+	Version() utils.ZapiVersion
 	// Scrape collects data from netappClient connection.
 	Scrape(netappClient *netapp.Client, ch chan<- prometheus.Metric) error
 }
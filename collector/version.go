@@ -0,0 +1,95 @@
+package collector
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics/utils"
+	"github.com/jenningsloy318/netapp_exporter/config"
+	"github.com/prometheus/common/log"
+)
+
+// ontapVersionTTL is how long a discovered version is cached, so that an
+// upgraded cluster is picked up without restarting the exporter.
+const ontapVersionTTL = time.Hour
+
+// OntapVersion is the ONTAP release of a target and the highest ZAPI version it supports.
+type OntapVersion struct {
+	Release    string
+	Zapi       utils.ZapiVersion
+	discovered time.Time
+}
+
+var (
+	ontapVersionsMu    sync.Mutex
+	ontapVersions      = make(map[string]*OntapVersion)
+	ontapVersionProbes = make(map[string]*ontapVersionProbe)
+)
+
+// ontapVersionProbe is a discovery in flight, concurrent requests for the same
+// target wait for it instead of probing the filer themselves.
+type ontapVersionProbe struct {
+	wg      sync.WaitGroup
+	version *OntapVersion
+	err     error
+}
+
+// GetOntapVersion returns the cached version of target, discovering it first if needed.
+func GetOntapVersion(target string, deviceConfig *config.DeviceConfig) (*OntapVersion, error) {
+	ontapVersionsMu.Lock()
+	if version, ok := ontapVersions[target]; ok && time.Since(version.discovered) < ontapVersionTTL {
+		ontapVersionsMu.Unlock()
+		return version, nil
+	}
+	if probe, ok := ontapVersionProbes[target]; ok {
+		ontapVersionsMu.Unlock()
+		probe.wg.Wait()
+		return probe.version, probe.err
+	}
+	probe := &ontapVersionProbe{}
+	probe.wg.Add(1)
+	ontapVersionProbes[target] = probe
+	ontapVersionsMu.Unlock()
+
+	probe.version, probe.err = discoverOntapVersion(target, deviceConfig)
+
+	ontapVersionsMu.Lock()
+	if probe.err == nil {
+		ontapVersions[target] = probe.version
+	}
+	delete(ontapVersionProbes, target)
+	ontapVersionsMu.Unlock()
+	probe.wg.Done()
+	return probe.version, probe.err
+}
+
+// ResetOntapVersions drops the cached versions, e.g. when the configuration is
+// reloaded and the credentials or the TLS settings of a target changed.
+func ResetOntapVersions() {
+	ontapVersionsMu.Lock()
+	defer ontapVersionsMu.Unlock()
+	ontapVersions = make(map[string]*OntapVersion)
+}
+
+func discoverOntapVersion(target string, deviceConfig *config.DeviceConfig) (*OntapVersion, error) {
+	_, probeClient, err := config.NewNetappClient(target, deviceConfig, config.ProbeZapiVersion)
+	if err != nil {
+		return nil, err
+	}
+	zapiVersion, err := metrics.GetOntapiVersion(probeClient)
+	if err != nil {
+		return nil, err
+	}
+	systemVersion, err := metrics.GetSystemVersion(probeClient)
+	if err != nil {
+		return nil, err
+	}
+	version := &OntapVersion{
+		Release:    systemVersion.Release,
+		Zapi:       zapiVersion,
+		discovered: time.Now(),
+	}
+	log.Infof("target %s runs ONTAP %s, using ZAPI version %s", target, version.Release, version.Zapi)
+	return version, nil
+}
@@ -2,9 +2,9 @@ package config
 
 import (
 	"fmt"
+	"github.com/creasty/defaults"
 	"github.com/pepabo/go-netapp/netapp"
 	"github.com/prometheus/common/log"
-	"github.com/creasty/defaults"
 	yaml "gopkg.in/yaml.v2"
 	"io/ioutil"
 	"sync"
@@ -21,11 +21,22 @@ type SafeConfig struct {
 }
 
 type DeviceConfig struct {
-	Group      string   `yaml:"group"`
-	Username   string   `yaml:"username"`
-	Password   string   `yaml:"password"`
-	Debug      bool     `yaml:"debug"`
-	PerfData []string   `yaml:"perfdata" default:"[\"system\", \"system:node\", \"nfsv3\", \"nfsv3:node\", \"lif\", \"lun\", \"aggregate\", \"disk\", \"workload\", \"processor\", \"processor:node\", \"volume:node\", \"volume:vserver\", \"volume\"]"`
+	Group    string    `yaml:"group"`
+	Username string    `yaml:"username"`
+	Password string    `yaml:"password"`
+	Debug    bool      `yaml:"debug"`
+	PerfData []string  `yaml:"perfdata" default:"[\"system\", \"system:node\", \"nfsv3\", \"nfsv3:node\", \"lif\", \"lun\", \"aggregate\", \"disk\", \"workload\", \"processor\", \"processor:node\", \"volume:node\", \"volume:vserver\", \"volume\", \"object_store_client_op\"]"`
+	Ems      EmsConfig `yaml:"ems"`
+	TLS      TLSConfig `yaml:"tls"`
+}
+
+type EmsConfig struct {
+	// LogEvents forwards every new EMS event as a JSON log line.
+	LogEvents bool `yaml:"log_events"`
+	// Sources are the addresses, e.g. the node management addresses, the
+	// NetAPP pushes EMS events from, besides the target itself.
+	Sources    []string `yaml:"sources"`
+	Severities []string `yaml:"severities" default:"[\"emergency\", \"alert\", \"critical\", \"error\"]"`
 }
 
 func (sc *SafeConfig) ReloadConfig(configFile string) error {
@@ -40,6 +51,12 @@ func (sc *SafeConfig) ReloadConfig(configFile string) error {
 		log.Errorf("Error parsing config file: %s", err)
 		return err
 	}
+	for target, deviceConfig := range c.Devices {
+		if _, err := NewTLSConfig(&deviceConfig.TLS); err != nil {
+			log.Errorf("Error in tls config of device %s: %s", target, err)
+			return err
+		}
+	}
 
 	sc.Lock()
 	sc.C = c
@@ -53,41 +70,81 @@ func (sc *SafeConfig) DeviceConfigForTarget(target string) (*DeviceConfig, error
 	sc.Lock()
 	defer sc.Unlock()
 	if deviceConfig, ok := sc.C.Devices[target]; ok {
-                defaults.Set(&deviceConfig)
+		defaults.Set(&deviceConfig)
 		return &DeviceConfig{
 			Group:    deviceConfig.Group,
 			Username: deviceConfig.Username,
 			Password: deviceConfig.Password,
 			Debug:    deviceConfig.Debug,
-                        PerfData: deviceConfig.PerfData,
+			PerfData: deviceConfig.PerfData,
+			Ems:      deviceConfig.Ems,
+			TLS:      deviceConfig.TLS,
 		}, nil
 	}
 	if deviceConfig, ok := sc.C.Devices["default"]; ok {
+		// only the EMS settings of the default device get their defaults, its
+		// other settings, e.g. perfdata, are left unset as before.
+		defaults.Set(&deviceConfig.Ems)
 		return &DeviceConfig{
 			Group:    deviceConfig.Group,
 			Username: deviceConfig.Username,
 			Password: deviceConfig.Password,
 			Debug:    deviceConfig.Debug,
-                        PerfData: deviceConfig.PerfData,
+			PerfData: deviceConfig.PerfData,
+			Ems:      deviceConfig.Ems,
+			TLS:      deviceConfig.TLS,
 		}, nil
 	}
 	return &DeviceConfig{}, fmt.Errorf("no credentials found for target %s", target)
 }
 
-func NewNetappClient(host string, deviceConfig *DeviceConfig) (string, *netapp.Client) {
+// TargetForAddress returns the target of the device which pushes EMS events from address.
+// The address is compared as is with the device keys and EMS sources, so only
+// those which are IP addresses match; host names are not resolved.
+func (sc *SafeConfig) TargetForAddress(address string) (string, bool) {
+	sc.RLock()
+	defer sc.RUnlock()
+	for target, deviceConfig := range sc.C.Devices {
+		if target == "default" {
+			continue
+		}
+		if target == address {
+			return target, true
+		}
+		for _, source := range deviceConfig.Ems.Sources {
+			if source == address {
+				return target, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ZAPI versions the client is created with, ProbeZapiVersion is old enough to
+// be accepted by any clustered ONTAP to discover the version it supports;
+// DefaultZapiVersion is used when the discovery fails.
+const (
+	ProbeZapiVersion   = "1.20"
+	DefaultZapiVersion = "1.130"
+)
+
+func NewNetappClient(host string, deviceConfig *DeviceConfig, version string) (string, *netapp.Client, error) {
 
 	_url := "https://%s/servlets/netapp.servlets.admin.XMLrequest_filer"
 	url := fmt.Sprintf(_url, host)
 
-	version := "1.130"
+	tlsConfig, err := NewTLSConfig(&deviceConfig.TLS)
+	if err != nil {
+		return deviceConfig.Group, nil, err
+	}
 
 	opts := &netapp.ClientOptions{
 		BasicAuthUser:     deviceConfig.Username,
 		BasicAuthPassword: deviceConfig.Password,
-		SSLVerify:         false,
+		SSLVerify:         deviceConfig.TLS.Verify(),
 		Debug:             deviceConfig.Debug,
 		Timeout:           30 * time.Second,
+		TLSConfig:         tlsConfig,
 	}
-	netappClient := netapp.NewClient(url, version, opts)
-	return deviceConfig.Group, netappClient
+	return deviceConfig.Group, netapp.NewClient(url, version, opts), nil
 }
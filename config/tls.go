@@ -0,0 +1,109 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+// TLSConfig is the TLS configuration of a device. The certificate of the device
+// is only verified when it is asked for, by ca_file, server_name or
+// insecure_skip_verify: false, as it never was before the tls settings.
+type TLSConfig struct {
+	// InsecureSkipVerify disables the verification of the certificate of the
+	// device when true, and enables it when false; unset, the certificate is
+	// only verified if CAFile or ServerName is set.
+	InsecureSkipVerify *bool `yaml:"insecure_skip_verify"`
+	// CAFile is a PEM file of the CA certificates the certificate of the
+	// device is verified against, instead of the CAs of the system.
+	CAFile string `yaml:"ca_file"`
+	// ServerName is the name the certificate is verified for, when the
+	// device is addressed by IP or by another name than its certificate has.
+	ServerName string `yaml:"server_name"`
+	// MinVersion is one of TLS10, TLS11, TLS12 or TLS13.
+	MinVersion string `yaml:"min_version"`
+	// CipherPolicy is either default, the cipher suites of Go, or modern,
+	// the forward secret AEAD cipher suites only.
+	CipherPolicy string `yaml:"cipher_policy" default:"default"`
+}
+
+var tlsVersions = map[string]uint16{
+	"TLS10": tls.VersionTLS10,
+	"TLS11": tls.VersionTLS11,
+	"TLS12": tls.VersionTLS12,
+	"TLS13": tls.VersionTLS13,
+}
+
+var tlsCipherPolicies = map[string][]uint16{
+	"default": nil,
+	"modern": {
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+		tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+	},
+}
+
+// Verify reports whether the certificate of the device is verified.
+func (cfg *TLSConfig) Verify() bool {
+	if cfg.InsecureSkipVerify != nil {
+		return !*cfg.InsecureSkipVerify
+	}
+	return cfg.CAFile != "" || cfg.ServerName != ""
+}
+
+// NewTLSConfig returns the client TLS configuration of the device.
+func NewTLSConfig(cfg *TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: !cfg.Verify(),
+		ServerName:         cfg.ServerName,
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := ioutil.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA file %s: %s", cfg.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no CA certificate found in %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.MinVersion != "" {
+		version, ok := tlsVersions[cfg.MinVersion]
+		if !ok {
+			return nil, fmt.Errorf("unknown TLS version %s", cfg.MinVersion)
+		}
+		tlsConfig.MinVersion = version
+	}
+
+	if cfg.CipherPolicy != "" {
+		cipherSuites, ok := tlsCipherPolicies[cfg.CipherPolicy]
+		if !ok {
+			return nil, fmt.Errorf("unknown cipher policy %s", cfg.CipherPolicy)
+		}
+		tlsConfig.CipherSuites = cipherSuites
+	}
+	return tlsConfig, nil
+}
+
+// IsTLSError reports whether err is a failed TLS handshake with a device,
+// e.g. its certificate is not trusted or it doesn't accept the TLS version.
+func IsTLSError(err error) bool {
+	var unknownAuthorityError x509.UnknownAuthorityError
+	var hostnameError x509.HostnameError
+	var certificateInvalidError x509.CertificateInvalidError
+	var recordHeaderError tls.RecordHeaderError
+	var alertError tls.AlertError
+	return errors.As(err, &unknownAuthorityError) ||
+		errors.As(err, &hostnameError) ||
+		errors.As(err, &certificateInvalidError) ||
+		errors.As(err, &recordHeaderError) ||
+		errors.As(err, &alertError)
+}
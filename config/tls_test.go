@@ -0,0 +1,167 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pepabo/go-netapp/netapp"
+)
+
+// writeCAFile writes the PEM of the DER certificate to a file in dir.
+func writeCAFile(t *testing.T, dir, name string, der []byte) string {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// otherCA returns the DER of a CA certificate which didn't sign anything.
+func otherCA(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "other CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	notPEM := filepath.Join(dir, "not.pem")
+	if err := ioutil.WriteFile(notPEM, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		cfg  TLSConfig
+		err  string
+	}{
+		{"unknown min_version", TLSConfig{MinVersion: "TLS9"}, "unknown TLS version"},
+		{"unknown cipher_policy", TLSConfig{CipherPolicy: "weak"}, "unknown cipher policy"},
+		{"missing ca_file", TLSConfig{CAFile: filepath.Join(dir, "missing.pem")}, "unable to read CA file"},
+		{"invalid ca_file", TLSConfig{CAFile: notPEM}, "no CA certificate found"},
+	}
+	for _, test := range tests {
+		_, err := NewTLSConfig(&test.cfg)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: got error %v, expected %q", test.name, err, test.err)
+		}
+	}
+}
+
+// TestTLSConfigVerify checks the certificate is only verified when asked for.
+func TestTLSConfigVerify(t *testing.T) {
+	skip, verify := true, false
+	tests := []struct {
+		name     string
+		cfg      TLSConfig
+		expected bool
+	}{
+		{"no tls settings", TLSConfig{}, false},
+		{"min_version only", TLSConfig{MinVersion: "TLS12"}, false},
+		{"ca_file", TLSConfig{CAFile: "ca.pem"}, true},
+		{"server_name", TLSConfig{ServerName: "cluster1"}, true},
+		{"insecure_skip_verify false", TLSConfig{InsecureSkipVerify: &verify}, true},
+		{"insecure_skip_verify true with ca_file", TLSConfig{InsecureSkipVerify: &skip, CAFile: "ca.pem"}, false},
+	}
+	for _, test := range tests {
+		if got := test.cfg.Verify(); got != test.expected {
+			t.Errorf("%s: Verify() = %v, expected %v", test.name, got, test.expected)
+		}
+	}
+}
+
+// TestNewNetappClientCAFile checks the certificate of the device is verified
+// against the CA file, and that a failed verification is a TLS error.
+func TestNewNetappClientCAFile(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<netapp version='1.20' xmlns='http://www.netapp.com/filer/admin'><results status="passed"/></netapp>`)
+	}))
+	defer srv.Close()
+	host := srv.Listener.Addr().String()
+
+	dir, err := ioutil.TempDir("", "tls_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	request := func(tlsConfig TLSConfig) error {
+		_, netappClient, err := NewNetappClient(host, &DeviceConfig{TLS: tlsConfig}, ProbeZapiVersion)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := netappClient.NewRequest("POST", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = netappClient.Do(req, nil)
+		return err
+	}
+
+	err = request(TLSConfig{CAFile: writeCAFile(t, dir, "other.pem", otherCA(t))})
+	if err == nil || !IsTLSError(err) {
+		t.Errorf("expected a TLS error for the certificate signed by another CA, got %v", err)
+	}
+
+	if err := request(TLSConfig{CAFile: writeCAFile(t, dir, "server.pem", srv.Certificate().Raw)}); err != nil {
+		t.Errorf("expected the certificate to be verified against the CA file, got %s", err)
+	}
+
+	if err := request(TLSConfig{}); err != nil {
+		t.Errorf("expected the certificate not to be verified without tls settings, got %s", err)
+	}
+}
+
+// TestIsTLSError checks errors other than failed TLS handshakes are not TLS errors.
+func TestIsTLSError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<netapp version='1.20' xmlns='http://www.netapp.com/filer/admin'><results status="passed"/></netapp>`)
+	}))
+	host := srv.Listener.Addr().String()
+	srv.Close()
+
+	netappClient := netapp.NewClient("https://"+host, ProbeZapiVersion, &netapp.ClientOptions{Timeout: 5 * time.Second})
+	req, err := netappClient.NewRequest("POST", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := netappClient.Do(req, nil); err == nil || IsTLSError(err) {
+		t.Errorf("expected a connection error which is not a TLS error, got %v", err)
+	}
+	if IsTLSError(fmt.Errorf("x509: certificate signed by unknown authority")) {
+		t.Errorf("expected the message alone not to make a TLS error")
+	}
+}
@@ -1,14 +1,30 @@
 module github.com/jenningsloy318/netapp_exporter
 
-go 1.12
+go 1.21
 
 require (
-	github.com/andreyvit/diff v0.0.0-20170406064948-c7f18ee00883 // indirect
 	github.com/creasty/defaults v1.5.1
 	github.com/pepabo/go-netapp v0.0.0-20190729091635-af16ec6d74df
 	github.com/prometheus/client_golang v0.9.3
 	github.com/prometheus/common v0.4.1
-	github.com/sergi/go-diff v1.0.0 // indirect
 	gopkg.in/alecthomas/kingpin.v2 v2.2.6
 	gopkg.in/yaml.v2 v2.2.1
 )
+
+require (
+	github.com/alecthomas/template v0.0.0-20160405071501-a0175ee3bccc // indirect
+	github.com/alecthomas/units v0.0.0-20151022065526-2efee857e7cf // indirect
+	github.com/andreyvit/diff v0.0.0-20170406064948-c7f18ee00883 // indirect
+	github.com/beorn7/perks v1.0.0 // indirect
+	github.com/golang/protobuf v1.3.1 // indirect
+	github.com/matttproud/golang_protobuf_extensions v1.0.1 // indirect
+	github.com/prometheus/client_model v0.0.0-20190129233127-fd36f4220a90 // indirect
+	github.com/prometheus/procfs v0.0.0-20190507164030-5867b95ac084 // indirect
+	github.com/sergi/go-diff v1.0.0 // indirect
+	github.com/sirupsen/logrus v1.2.0 // indirect
+	golang.org/x/crypto v0.0.0-20180904163835-0709b304e793 // indirect
+	golang.org/x/sys v0.0.0-20181116152217-5ac8a444bdc5 // indirect
+)
+
+// ClientOptions.TLSConfig, see third_party/go-netapp/README.md.
+replace github.com/pepabo/go-netapp => ./third_party/go-netapp
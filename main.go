@@ -1,10 +1,18 @@
 package main
 
 import (
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/jenningsloy318/netapp_exporter/collector"
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics"
 	"github.com/jenningsloy318/netapp_exporter/config"
+	"github.com/jenningsloy318/netapp_exporter/receiver"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prometheus/common/log"
@@ -20,6 +28,22 @@ var (
 		"web.listen-address",
 		"Address to listen on for web interface and telemetry.",
 	).Default(":9609").String()
+	emsWebhook = kingpin.Flag(
+		"ems.webhook",
+		"Receive EMS events sent to a rest-api destination on /ems.",
+	).Bool()
+	emsWebhookTokenFile = kingpin.Flag(
+		"ems.webhook-token-file",
+		"File holding the token EMS notifications must send to /ems, as bearer token or token URL parameter; no token is required if empty.",
+	).String()
+	emsSyslogUDPAddress = kingpin.Flag(
+		"ems.syslog-udp-address",
+		"Address to receive EMS events sent to a syslog destination over UDP, disabled if empty.",
+	).String()
+	emsSyslogTCPAddress = kingpin.Flag(
+		"ems.syslog-tcp-address",
+		"Address to receive EMS events sent to a syslog destination over TCP, disabled if empty.",
+	).String()
 	sc = &config.SafeConfig{
 		C: &config.Config{},
 	}
@@ -42,12 +66,35 @@ func metricsHandler() http.HandlerFunc {
 		var err error
 		if deviceConfig, err = sc.DeviceConfigForTarget(target); err != nil {
 			log.Errorf("Error getting credentialfor target %s, error: %s", target, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		groupName, netappClient := config.NewNetappClient(target, deviceConfig)
-		collector := collector.New(groupName, netappClient, deviceConfig)
+		version := config.DefaultZapiVersion
+		ontapVersion, err := collector.GetOntapVersion(target, deviceConfig)
+		if err != nil {
+			log.Errorf("Error discovering ONTAP version of target %s, using ZAPI %s, error: %s", target, version, err)
+		} else {
+			version = ontapVersion.Zapi.String()
+		}
+
+		groupName, netappClient, err := config.NewNetappClient(target, deviceConfig, version)
+		if err != nil {
+			log.Errorf("Error creating client for target %s, error: %s", target, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// a failed TLS handshake fails every scrape the same way, it is
+		// answered with the error rather than reported as up 0.
+		if _, err := collector.GetClusterIdentity(netappClient); err != nil && config.IsTLSError(err) {
+			log.Errorf("Error connecting to target %s, error: %s", target, err)
+			http.Error(w, fmt.Sprintf("error connecting to target %s: %s", target, err), http.StatusBadGateway)
+			return
+		}
+		collector := collector.New(groupName, netappClient, deviceConfig, ontapVersion)
 		registry.MustRegister(collector)
+		// the pushed EMS events are exported even when the target is down.
+		registry.MustRegister(metrics.NewEmsReceiverCollector(target))
 
 		gatherers := prometheus.Gatherers{
 			prometheus.DefaultGatherer,
@@ -78,10 +125,45 @@ func main() {
 	if err := sc.ReloadConfig(*configFile); err != nil {
 		log.Fatalf("Error parsing config file: %s", err)
 	}
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			if err := sc.ReloadConfig(*configFile); err != nil {
+				log.Errorf("Error reloading config file, keeping the old one: %s", err)
+				continue
+			}
+			// the versions were discovered with the old credentials and TLS settings.
+			collector.ResetOntapVersions()
+		}
+	}()
 
 	http.Handle("/netapp", metricsHandler()) // Regular metrics endpoint for local netapp metrics.
 	http.Handle("/metrics", promhttp.Handler())
 
+	var emsWebhookToken string
+	if *emsWebhookTokenFile != "" {
+		token, err := ioutil.ReadFile(*emsWebhookTokenFile)
+		if err != nil {
+			log.Fatalf("Error reading EMS webhook token file: %s", err)
+		}
+		emsWebhookToken = strings.TrimSpace(string(token))
+	}
+	emsReceiver := receiver.New(sc, emsWebhookToken)
+	if *emsWebhook {
+		http.Handle("/ems", emsReceiver.Handler())
+	}
+	if *emsSyslogUDPAddress != "" {
+		if err := emsReceiver.ListenSyslogUDP(*emsSyslogUDPAddress); err != nil {
+			log.Fatalf("Error listening for EMS syslog messages: %s", err)
+		}
+	}
+	if *emsSyslogTCPAddress != "" {
+		if err := emsReceiver.ListenSyslogTCP(*emsSyslogTCPAddress); err != nil {
+			log.Fatalf("Error listening for EMS syslog messages: %s", err)
+		}
+	}
+
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`<html>
             <head>
@@ -0,0 +1,197 @@
+package receiver
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jenningsloy318/netapp_exporter/collector/metrics"
+	"github.com/jenningsloy318/netapp_exporter/config"
+	"github.com/prometheus/common/log"
+)
+
+// maxMessageSize is the largest EMS message accepted, by syslog or the webhook.
+const maxMessageSize = 64 * 1024
+
+// maxRetryDelay is the longest wait before reading again from a syslog socket
+// after a temporary error.
+const maxRetryDelay = time.Second
+
+// Receiver turns the EMS events pushed by the NetAPPs into metrics of the
+// target the source address belongs to.
+type Receiver struct {
+	sc *config.SafeConfig
+	// webhookToken, if set, must be sent with every webhook notification.
+	webhookToken string
+}
+
+func New(sc *config.SafeConfig, webhookToken string) *Receiver {
+	return &Receiver{
+		sc:           sc,
+		webhookToken: webhookToken,
+	}
+}
+
+// record counts event for the device which sent it from address, it reports
+// false if address doesn't belong to a configured device.
+func (r *Receiver) record(address string, event *metrics.EmsEvent) bool {
+	target, ok := r.sc.TargetForAddress(address)
+	if !ok {
+		log.Warnf("Dropping EMS event %s from unknown source %s", event.MessageName, address)
+		return false
+	}
+	var logEvent bool
+	if deviceConfig, err := r.sc.DeviceConfigForTarget(target); err == nil {
+		logEvent = deviceConfig.Ems.LogEvents
+	}
+	metrics.RecordEmsEvent(target, event, logEvent)
+	return true
+}
+
+func (r *Receiver) recordSyslog(address string, line string) {
+	event, err := metrics.ParseEmsSyslog(line)
+	if err != nil {
+		log.Debugf("Error parsing syslog message from %s, error: %s", address, err)
+		return
+	}
+	r.record(address, event)
+}
+
+// ListenSyslogUDP receives EMS events sent to a syslog destination over UDP.
+func (r *Receiver) ListenSyslogUDP(address string) error {
+	conn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return err
+	}
+	log.Infof("Receiving EMS syslog messages on udp %s", address)
+	go func() {
+		buf := make([]byte, maxMessageSize)
+		var delay time.Duration
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				var ok bool
+				if delay, ok = retryDelay(err, delay); !ok {
+					log.Errorf("Error reading syslog message, stop receiving on udp %s, error: %s", address, err)
+					return
+				}
+				log.Errorf("Error reading syslog message, retrying in %s, error: %s", delay, err)
+				time.Sleep(delay)
+				continue
+			}
+			delay = 0
+			r.recordSyslog(addr.(*net.UDPAddr).IP.String(), string(buf[:n]))
+		}
+	}()
+	return nil
+}
+
+// ListenSyslogTCP receives EMS events sent to a syslog destination over TCP,
+// one message per line.
+func (r *Receiver) ListenSyslogTCP(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	log.Infof("Receiving EMS syslog messages on tcp %s", address)
+	go func() {
+		var delay time.Duration
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				var ok bool
+				if delay, ok = retryDelay(err, delay); !ok {
+					log.Errorf("Error accepting syslog connection, stop receiving on tcp %s, error: %s", address, err)
+					return
+				}
+				log.Errorf("Error accepting syslog connection, retrying in %s, error: %s", delay, err)
+				time.Sleep(delay)
+				continue
+			}
+			delay = 0
+			go r.serveSyslogConn(conn)
+		}
+	}()
+	return nil
+}
+
+// retryDelay returns how long to wait before using a socket again after err,
+// doubling the previous delay. It reports false if err is not temporary, e.g.
+// the socket is closed, and the socket should not be used anymore.
+func retryDelay(err error, delay time.Duration) (time.Duration, bool) {
+	if ne, ok := err.(net.Error); !ok || !ne.Temporary() {
+		return 0, false
+	}
+	if delay == 0 {
+		delay = 5 * time.Millisecond
+	} else {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay, true
+}
+
+func (r *Receiver) serveSyslogConn(conn net.Conn) {
+	defer conn.Close()
+	address := conn.RemoteAddr().(*net.TCPAddr).IP.String()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 4096), maxMessageSize)
+	for scanner.Scan() {
+		r.recordSyslog(address, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		log.Errorf("Error reading syslog messages from %s, error: %s", address, err)
+	}
+}
+
+// Handler receives EMS events sent to a rest-api destination. With a webhook
+// token, the token must be sent as bearer token or as the token parameter of
+// the URL, as ONTAP can't add headers to the notifications.
+func (r *Receiver) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(w, "EMS notifications must be POSTed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !r.authorized(req) {
+			http.Error(w, "invalid or missing token", http.StatusUnauthorized)
+			return
+		}
+		address, _, err := net.SplitHostPort(req.RemoteAddr)
+		if err != nil {
+			address = req.RemoteAddr
+		}
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, maxMessageSize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		event, err := metrics.ParseEmsXML(body)
+		if err != nil {
+			log.Debugf("Error parsing EMS notification from %s, error: %s", address, err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !r.record(address, event) {
+			http.Error(w, "unknown source "+address, http.StatusForbidden)
+		}
+	}
+}
+
+// authorized reports whether req carries the webhook token, if one is set.
+func (r *Receiver) authorized(req *http.Request) bool {
+	if r.webhookToken == "" {
+		return true
+	}
+	token := req.URL.Query().Get("token")
+	if auth := req.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		token = strings.TrimPrefix(auth, "Bearer ")
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(r.webhookToken)) == 1
+}
@@ -0,0 +1,34 @@
+package receiver
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthorized(t *testing.T) {
+	tests := []struct {
+		token  string
+		url    string
+		header string
+		want   bool
+	}{
+		{"", "/ems", "", true},
+		{"secret", "/ems", "", false},
+		{"secret", "/ems?token=secret", "", true},
+		{"secret", "/ems?token=wrong", "", false},
+		{"secret", "/ems", "Bearer secret", true},
+		{"secret", "/ems", "Bearer wrong", false},
+		{"secret", "/ems?token=secret", "Bearer wrong", false},
+		{"secret", "/ems", "Basic secret", false},
+	}
+	for _, test := range tests {
+		r := New(nil, test.token)
+		req := httptest.NewRequest("POST", test.url, nil)
+		if test.header != "" {
+			req.Header.Set("Authorization", test.header)
+		}
+		if got := r.authorized(req); got != test.want {
+			t.Errorf("authorized(%q, %q) with token %q = %v; want %v", test.url, test.header, test.token, got, test.want)
+		}
+	}
+}
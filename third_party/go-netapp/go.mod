@@ -0,0 +1,3 @@
+module github.com/pepabo/go-netapp
+
+go 1.12